@@ -31,6 +31,9 @@ import (
 
 	"github.com/go-logr/logr"
 	"github.com/spf13/pflag"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -74,6 +77,17 @@ var (
 	webhookPort                  int
 	syncPeriod                   time.Duration
 	healthAddr                   string
+	gcpProject                   string
+	pubsubTopic                  string
+	featureGates                 string
+	auditLogPath                 string
+	auditLogMaxSize              int
+	snsTopicARN                  string
+	traceSampleRate              float64
+	machineWatchAnnotations      []string
+	respawnPausedClusters        bool
+	clusterWatchConditions       []string
+	managedNamespaces            []string
 )
 
 const (
@@ -81,6 +95,9 @@ const (
 	defaultWorkers            = 20
 	defaultReloaderReportTime = 10 // time is in second
 	defaulReportMode          = int(controllers.CollectFromManagementCluster)
+
+	// otlpEndpointEnv is the standard OpenTelemetry environment variable configuring where traces are exported
+	otlpEndpointEnv = "OTEL_EXPORTER_OTLP_ENDPOINT"
 )
 
 // Add RBAC for the authorized diagnostics endpoint.
@@ -101,6 +118,7 @@ func main() {
 	pflag.Parse()
 
 	reportMode = controllers.ReportMode(tmpReportMode)
+	setupFeatureGates()
 
 	ctrl.SetLogger(klog.Background())
 
@@ -138,12 +156,15 @@ func main() {
 	controllers.RegisterFeatures(d, setupLog)
 
 	controllers.SetManagementRecorder(mgr.GetEventRecorderFor("notification-recorder"))
+	setupEvaluationPublisher(ctx)
+	setupHealthTransitionPublisher(ctx)
+	setupAuditLogger(ctx)
+	setupTracing(ctx)
 
-	var clusterHealthCheckController controller.Controller
 	clusterHealthCheckReconciler := getClusterHealthCheckReconciler(mgr)
 	clusterHealthCheckReconciler.Deployer = d
 
-	clusterHealthCheckController, err = clusterHealthCheckReconciler.SetupWithManager(mgr)
+	clusterHealthCheckController, err := clusterHealthCheckReconciler.SetupWithManager(mgr)
 	if err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "ClusterHealthCheck")
 		os.Exit(1)
@@ -177,9 +198,7 @@ func main() {
 
 	setupChecks(mgr)
 
-	go capiWatchers(ctx, mgr,
-		clusterHealthCheckReconciler, clusterHealthCheckController,
-		setupLog)
+	go capiWatchers(ctx, mgr, clusterHealthCheckReconciler, clusterHealthCheckController, setupLog)
 
 	setupLog.Info("starting manager")
 	if err := mgr.Start(ctx); err != nil {
@@ -233,6 +252,139 @@ func initFlags(fs *pflag.FlagSet) {
 	fs.DurationVar(&syncPeriod, "sync-period", defaultSyncPeriod*time.Minute,
 		fmt.Sprintf("The minimum interval at which watched resources are reconciled (e.g. 15m). Default: %d minutes",
 			defaultSyncPeriod))
+
+	fs.StringVar(&gcpProject, "gcp-project", "",
+		"GCP project hosting the Pub/Sub topic evaluation results are published to. Requires pubsub-topic")
+
+	fs.StringVar(&pubsubTopic, "pubsub-topic", "",
+		"If set, along with gcp-project, after each evaluation results are published to this Pub/Sub topic")
+
+	fs.StringVar(&featureGates, "feature-gates", "",
+		"Comma separated list of experimental features to enable (e.g. CELExpressions,AutoRemediation)")
+
+	fs.StringVar(&auditLogPath, "audit-log-path", "",
+		"If set, an audit event (audit.k8s.io/v1 format) is appended to this file for each evaluation")
+
+	const defaultAuditLogMaxSize = 100
+	fs.IntVar(&auditLogMaxSize, "audit-log-max-size-mb", defaultAuditLogMaxSize,
+		fmt.Sprintf("Size, in megabytes, at which audit log file is rotated. 0 disables rotation. Default %d",
+			defaultAuditLogMaxSize))
+
+	fs.StringVar(&snsTopicARN, "aws-sns-topic-arn", "",
+		"If set, after each health transition results are published to this AWS SNS topic. Default credentials chain is used")
+
+	const defaultTraceSampleRate = 0.1
+	fs.Float64Var(&traceSampleRate, "trace-sample-rate", defaultTraceSampleRate,
+		fmt.Sprintf("Fraction (0.0 to 1.0) of reconciliations traced. Traces are exported via OTLP when %s is set. Default %v",
+			otlpEndpointEnv, defaultTraceSampleRate))
+
+	fs.StringSliceVar(&machineWatchAnnotations, "machine-watch-annotations", nil,
+		"Comma separated list of CAPI Machine annotation keys. Any change to one of those annotations triggers a reconciliation")
+
+	fs.BoolVar(&respawnPausedClusters, "respawn-paused-clusters", false,
+		"If set, CAPI Clusters created paused are reconciled on creation as well, so they are processed once unpaused")
+
+	fs.StringSliceVar(&clusterWatchConditions, "cluster-watch-conditions", nil,
+		"Comma separated list of CAPI Cluster condition types (e.g. ControlPlaneReady,InfrastructureReady). "+
+			"A change to Status or Reason of one of those conditions triggers a reconciliation")
+
+	fs.StringSliceVar(&managedNamespaces, "managed-namespaces", nil,
+		"Comma separated list of namespaces. If set, only ClusterSummaries in those namespaces trigger a reconciliation")
+}
+
+// setupFeatureGates enables experimental features requested via feature-gates flag
+func setupFeatureGates() {
+	if err := controllers.SetFeatureGates(featureGates); err != nil {
+		setupLog.Error(err, "invalid feature gates")
+		os.Exit(1)
+	}
+}
+
+// setupEvaluationPublisher configures, if requested, where evaluation results are published
+func setupEvaluationPublisher(ctx context.Context) {
+	if gcpProject == "" || pubsubTopic == "" {
+		return
+	}
+
+	publisher, err := controllers.NewPubSubPublisher(ctx, gcpProject, pubsubTopic)
+	if err != nil {
+		setupLog.Error(err, "unable to create pubsub publisher")
+		os.Exit(1)
+	}
+
+	go func() {
+		<-ctx.Done()
+		if err := publisher.Close(); err != nil {
+			setupLog.Error(err, "failed to close pubsub publisher")
+		}
+	}()
+
+	controllers.SetEvaluationPublisher(publisher)
+}
+
+// setupHealthTransitionPublisher configures, if requested, the SNS topic health transitions are published to
+func setupHealthTransitionPublisher(ctx context.Context) {
+	if snsTopicARN == "" {
+		return
+	}
+
+	publisher, err := controllers.NewSNSPublisher(ctx, snsTopicARN)
+	if err != nil {
+		setupLog.Error(err, "unable to create sns publisher")
+		os.Exit(1)
+	}
+
+	controllers.SetHealthTransitionPublisher(publisher)
+}
+
+// setupAuditLogger configures, if requested, the file evaluation audit events are written to
+func setupAuditLogger(ctx context.Context) {
+	if auditLogPath == "" {
+		return
+	}
+
+	auditLogger, err := controllers.NewAuditLogger(auditLogPath, auditLogMaxSize)
+	if err != nil {
+		setupLog.Error(err, "unable to open audit log file")
+		os.Exit(1)
+	}
+
+	go func() {
+		<-ctx.Done()
+		if err := auditLogger.Close(); err != nil {
+			setupLog.Error(err, "failed to close audit log file")
+		}
+	}()
+
+	controllers.SetAuditLogger(auditLogger)
+}
+
+// setupTracing sets the global TracerProvider used to trace reconciliations
+func setupTracing(ctx context.Context) {
+	var opts []sdktrace.TracerProviderOption
+	if os.Getenv(otlpEndpointEnv) != "" {
+		exporter, err := otlptracegrpc.New(ctx)
+		if err != nil {
+			setupLog.Error(err, "unable to create OTLP trace exporter")
+			os.Exit(1)
+		}
+		opts = append(opts, sdktrace.WithBatcher(exporter))
+	}
+
+	tracerProvider, err := controllers.NewTracerProvider(traceSampleRate, opts...)
+	if err != nil {
+		setupLog.Error(err, "invalid trace-sample-rate")
+		os.Exit(1)
+	}
+
+	go func() {
+		<-ctx.Done()
+		if err := tracerProvider.Shutdown(context.Background()); err != nil {
+			setupLog.Error(err, "failed to shutdown tracer provider")
+		}
+	}()
+
+	otel.SetTracerProvider(tracerProvider)
 }
 
 func setupChecks(mgr ctrl.Manager) {
@@ -244,6 +396,13 @@ func setupChecks(mgr ctrl.Manager) {
 		setupLog.Error(err, "unable to set up ready check")
 		os.Exit(1)
 	}
+	// Live health status is served by the diagnostics server, so it gets same authentication/authorization as metrics.
+	// Callers need get on ClusterHealthStatusesPath nonResourceURL (see health-status-reader ClusterRole)
+	if err := mgr.AddMetricsServerExtraHandler(controllers.ClusterHealthStatusesPath,
+		controllers.ClusterHealthStatusHandler()); err != nil {
+		setupLog.Error(err, "unable to set up cluster health statuses handler")
+		os.Exit(1)
+	}
 }
 
 // capiCRDHandler restarts process if a CAPI CRD is updated
@@ -308,18 +467,31 @@ func capiWatchers(ctx context.Context, mgr ctrl.Manager, clusterHealthCheckRecon
 
 func getClusterHealthCheckReconciler(mgr manager.Manager) *controllers.ClusterHealthCheckReconciler {
 	return &controllers.ClusterHealthCheckReconciler{
-		Client:               mgr.GetClient(),
-		Scheme:               mgr.GetScheme(),
-		ConcurrentReconciles: concurrentReconciles,
-		Mux:                  sync.Mutex{},
-		ShardKey:             shardKey,
-		ClusterMap:           make(map[corev1.ObjectReference]*libsveltosset.Set),
-		CHCToClusterMap:      make(map[types.NamespacedName]*libsveltosset.Set),
-		ClusterHealthChecks:  make(map[corev1.ObjectReference]libsveltosv1alpha1.Selector),
-		ClusterLabels:        make(map[corev1.ObjectReference]map[string]string),
-		HealthCheckMap:       make(map[corev1.ObjectReference]*libsveltosset.Set),
-		CHCToHealthCheckMap:  make(map[types.NamespacedName]*libsveltosset.Set),
+		Client:                  mgr.GetClient(),
+		Scheme:                  mgr.GetScheme(),
+		ConcurrentReconciles:    concurrentReconciles,
+		Mux:                     sync.Mutex{},
+		ShardKey:                shardKey,
+		MachineWatchAnnotations: machineWatchAnnotations,
+		RespawnPausedClusters:   respawnPausedClusters,
+		ClusterWatchConditions:  getClusterWatchConditions(),
+		ManagedNamespaces:       managedNamespaces,
+		ClusterMap:              make(map[corev1.ObjectReference]*libsveltosset.Set),
+		CHCToClusterMap:         make(map[types.NamespacedName]*libsveltosset.Set),
+		ClusterHealthChecks:     make(map[corev1.ObjectReference]libsveltosv1alpha1.Selector),
+		ClusterLabels:           make(map[corev1.ObjectReference]map[string]string),
+		HealthCheckMap:          make(map[corev1.ObjectReference]*libsveltosset.Set),
+		CHCToHealthCheckMap:     make(map[types.NamespacedName]*libsveltosset.Set),
+	}
+}
+
+// getClusterWatchConditions returns the CAPI Cluster condition types set via cluster-watch-conditions flag
+func getClusterWatchConditions() []clusterv1.ConditionType {
+	conditions := make([]clusterv1.ConditionType, len(clusterWatchConditions))
+	for i := range clusterWatchConditions {
+		conditions[i] = clusterv1.ConditionType(clusterWatchConditions[i])
 	}
+	return conditions
 }
 
 // getDiagnosticsOptions returns metrics options which can be used to configure a Manager.
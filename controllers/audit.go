@@ -0,0 +1,254 @@
+/*
+Copyright 2024. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	auditv1 "k8s.io/apiserver/pkg/apis/audit/v1"
+	"k8s.io/apiserver/pkg/authentication/serviceaccount"
+
+	libsveltosv1alpha1 "github.com/projectsveltos/libsveltos/api/v1alpha1"
+	logs "github.com/projectsveltos/libsveltos/lib/logsettings"
+)
+
+const (
+	// podNamespaceEnv and podServiceAccountEnv, set via downward API, identify the service account
+	// recorded as evaluator in audit events
+	podNamespaceEnv      = "POD_NAMESPACE"
+	podServiceAccountEnv = "POD_SERVICE_ACCOUNT"
+
+	defaultAuditNamespace      = "projectsveltos"
+	defaultAuditServiceAccount = "hc-manager"
+
+	auditVerb = "evaluate"
+
+	auditClusterAnnotation = "healthcheck.projectsveltos.io/cluster"
+	auditResultAnnotation  = "healthcheck.projectsveltos.io/result"
+	auditMessageAnnotation = "healthcheck.projectsveltos.io/message"
+
+	auditLogFilePermission = 0600
+	bytesInMegabyte        = 1024 * 1024
+)
+
+// AuditLogger writes, one per line, audit.k8s.io/v1 Events to a file.
+// When file size would exceed the configured maximum, file is rotated: current
+// content is moved to <path>.1 (replacing any previous one) and a new file is started.
+type AuditLogger struct {
+	mu      sync.Mutex
+	path    string
+	maxSize int64
+	file    *os.File
+	size    int64
+}
+
+// NewAuditLogger returns an AuditLogger appending to file at path.
+// maxSizeMB is the size, in megabytes, at which file is rotated. 0 means never rotate.
+func NewAuditLogger(path string, maxSizeMB int) (*AuditLogger, error) {
+	a := &AuditLogger{
+		path:    path,
+		maxSize: int64(maxSizeMB) * bytesInMegabyte,
+	}
+
+	if err := a.open(); err != nil {
+		return nil, err
+	}
+
+	return a, nil
+}
+
+func (a *AuditLogger) open() error {
+	f, err := os.OpenFile(a.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, auditLogFilePermission)
+	if err != nil {
+		return err
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+
+	a.file = f
+	a.size = info.Size()
+	return nil
+}
+
+// rotate moves current content to <path>.1 and starts a new file.
+// On any failure, a usable file is reopened (if possible) so audit logging can continue.
+func (a *AuditLogger) rotate() error {
+	closeErr := a.file.Close()
+	a.file = nil
+
+	var renameErr error
+	if closeErr == nil {
+		renameErr = os.Rename(a.path, a.path+".1")
+	}
+
+	if err := a.open(); err != nil {
+		return err
+	}
+
+	if closeErr != nil {
+		return closeErr
+	}
+	return renameErr
+}
+
+// Log writes event to the audit file
+func (a *AuditLogger) Log(event *auditv1.Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	// A previous failure might have left no usable file
+	if a.file == nil {
+		if err := a.open(); err != nil {
+			return err
+		}
+	}
+
+	var rotateErr error
+	if a.maxSize > 0 && a.size > 0 && a.size+int64(len(data)) > a.maxSize {
+		rotateErr = a.rotate()
+		if a.file == nil {
+			return fmt.Errorf("failed to rotate audit log: %w", rotateErr)
+		}
+	}
+
+	n, err := a.file.Write(data)
+	a.size += int64(n)
+	if err != nil {
+		return err
+	}
+
+	if rotateErr != nil {
+		return fmt.Errorf("event written but failed to rotate audit log: %w", rotateErr)
+	}
+	return nil
+}
+
+// Close closes the audit file
+func (a *AuditLogger) Close() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.file == nil {
+		return nil
+	}
+
+	err := a.file.Close()
+	a.file = nil
+	return err
+}
+
+var (
+	auditLogger *AuditLogger
+)
+
+// SetAuditLogger sets the AuditLogger used to record an audit event for each evaluation.
+// If never set, no audit event is recorded.
+func SetAuditLogger(a *AuditLogger) {
+	auditLogger = a
+}
+
+func getAuditLogger() *AuditLogger {
+	return auditLogger
+}
+
+// getAuditUsername returns the service account username recorded as evaluator in audit events.
+// Service account is identified via POD_NAMESPACE and POD_SERVICE_ACCOUNT environment variables.
+func getAuditUsername() string {
+	namespace := os.Getenv(podNamespaceEnv)
+	if namespace == "" {
+		namespace = defaultAuditNamespace
+	}
+
+	name := os.Getenv(podServiceAccountEnv)
+	if name == "" {
+		name = defaultAuditServiceAccount
+	}
+
+	return serviceaccount.MakeUsername(namespace, name)
+}
+
+// getAuditEvent returns the audit event recording evaluation results for a ClusterHealthCheck/cluster pair
+func getAuditEvent(clusterNamespace, clusterName string, clusterType libsveltosv1alpha1.ClusterType,
+	chc *libsveltosv1alpha1.ClusterHealthCheck, conditions []libsveltosv1alpha1.Condition,
+	evaluatedAt time.Time, logger logr.Logger) *auditv1.Event {
+
+	message, _ := getNotificationMessage(clusterNamespace, clusterName, clusterType, conditions, logger)
+	result := getHealthStatus(conditions)
+
+	timestamp := metav1.NewMicroTime(evaluatedAt)
+	return &auditv1.Event{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Event",
+			APIVersion: auditv1.SchemeGroupVersion.String(),
+		},
+		Level:   auditv1.LevelMetadata,
+		AuditID: uuid.NewUUID(),
+		Stage:   auditv1.StageResponseComplete,
+		Verb:    auditVerb,
+		User: authenticationv1.UserInfo{
+			Username: getAuditUsername(),
+		},
+		ObjectRef: &auditv1.ObjectReference{
+			Resource:   "clusterhealthchecks",
+			Name:       chc.Name,
+			UID:        chc.UID,
+			APIGroup:   libsveltosv1alpha1.GroupVersion.Group,
+			APIVersion: libsveltosv1alpha1.GroupVersion.Version,
+		},
+		RequestReceivedTimestamp: timestamp,
+		StageTimestamp:           timestamp,
+		Annotations: map[string]string{
+			auditClusterAnnotation: fmt.Sprintf("%s:%s/%s", clusterType, clusterNamespace, clusterName),
+			auditResultAnnotation:  result,
+			auditMessageAnnotation: message,
+		},
+	}
+}
+
+// auditEvaluation records an audit event for evaluation results of a ClusterHealthCheck/cluster pair.
+// Failures are logged but never returned, as evaluation itself succeeded.
+func auditEvaluation(clusterNamespace, clusterName string, clusterType libsveltosv1alpha1.ClusterType,
+	chc *libsveltosv1alpha1.ClusterHealthCheck, conditions []libsveltosv1alpha1.Condition, logger logr.Logger) {
+
+	a := getAuditLogger()
+	if a == nil {
+		return
+	}
+
+	event := getAuditEvent(clusterNamespace, clusterName, clusterType, chc, conditions, time.Now(), logger)
+	if err := a.Log(event); err != nil {
+		logger.V(logs.LogInfo).Info(fmt.Sprintf("failed to write audit event: %v", err))
+	}
+}
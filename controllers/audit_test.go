@@ -0,0 +1,189 @@
+/*
+Copyright 2024. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	auditv1 "k8s.io/apiserver/pkg/apis/audit/v1"
+	"k8s.io/klog/v2/textlogger"
+
+	"github.com/projectsveltos/healthcheck-manager/controllers"
+	"github.com/projectsveltos/healthcheck-manager/controllers/testhelpers"
+	libsveltosv1alpha1 "github.com/projectsveltos/libsveltos/api/v1alpha1"
+)
+
+func readAuditEvents(path string) []auditv1.Event {
+	f, err := os.Open(path)
+	Expect(err).To(BeNil())
+	defer f.Close()
+
+	events := make([]auditv1.Event, 0)
+	const maxLineSize = 1024 * 1024
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, maxLineSize), maxLineSize)
+	for scanner.Scan() {
+		event := auditv1.Event{}
+		Expect(json.Unmarshal(scanner.Bytes(), &event)).To(Succeed())
+		events = append(events, event)
+	}
+	Expect(scanner.Err()).To(BeNil())
+	return events
+}
+
+var _ = Describe("Audit", func() {
+	var chc *libsveltosv1alpha1.ClusterHealthCheck
+	var auditPath string
+
+	BeforeEach(func() {
+		chc = testhelpers.NewClusterHealthCheck().WithName(randomString()).Build()
+
+		auditPath = filepath.Join(GinkgoT().TempDir(), "audit.log")
+	})
+
+	AfterEach(func() {
+		controllers.SetAuditLogger(nil)
+	})
+
+	It("getAuditEvent returns an audit.k8s.io/v1 event with evaluation results", func() {
+		clusterNamespace := randomString()
+		clusterName := randomString()
+		failingMessage := randomString()
+		conditions := []libsveltosv1alpha1.Condition{
+			{
+				Type:    libsveltosv1alpha1.ConditionType(randomString()),
+				Status:  corev1.ConditionFalse,
+				Message: failingMessage,
+			},
+		}
+
+		evaluatedAt := time.Now()
+		event := controllers.GetAuditEvent(clusterNamespace, clusterName, libsveltosv1alpha1.ClusterTypeCapi, chc,
+			conditions, evaluatedAt, textlogger.NewLogger(textlogger.NewConfig(textlogger.Verbosity(1))))
+
+		Expect(event.APIVersion).To(Equal("audit.k8s.io/v1"))
+		Expect(event.Kind).To(Equal("Event"))
+		Expect(event.AuditID).ToNot(BeEmpty())
+		Expect(event.Level).To(Equal(auditv1.LevelMetadata))
+		Expect(event.Stage).To(Equal(auditv1.StageResponseComplete))
+		Expect(event.User.Username).ToNot(BeEmpty())
+		Expect(event.ObjectRef).ToNot(BeNil())
+		Expect(event.ObjectRef.Name).To(Equal(chc.Name))
+		Expect(event.StageTimestamp.Time.Equal(evaluatedAt)).To(BeTrue())
+		Expect(event.Annotations["healthcheck.projectsveltos.io/cluster"]).To(
+			Equal("Capi:" + clusterNamespace + "/" + clusterName))
+		Expect(event.Annotations["healthcheck.projectsveltos.io/result"]).To(Equal("Degraded"))
+		Expect(event.Annotations["healthcheck.projectsveltos.io/message"]).To(ContainSubstring(failingMessage))
+
+		conditions[0].Status = corev1.ConditionTrue
+		event = controllers.GetAuditEvent(clusterNamespace, clusterName, libsveltosv1alpha1.ClusterTypeCapi, chc,
+			conditions, evaluatedAt, textlogger.NewLogger(textlogger.NewConfig(textlogger.Verbosity(1))))
+		Expect(event.Annotations["healthcheck.projectsveltos.io/result"]).To(Equal("Healthy"))
+	})
+
+	It("auditEvaluation appends valid audit JSON to audit file", func() {
+		auditLogger, err := controllers.NewAuditLogger(auditPath, 0)
+		Expect(err).To(BeNil())
+		defer auditLogger.Close()
+		controllers.SetAuditLogger(auditLogger)
+
+		const evaluations = 3
+		for i := 0; i < evaluations; i++ {
+			controllers.AuditEvaluation(randomString(), randomString(), libsveltosv1alpha1.ClusterTypeSveltos, chc,
+				nil, textlogger.NewLogger(textlogger.NewConfig(textlogger.Verbosity(1))))
+		}
+
+		events := readAuditEvents(auditPath)
+		Expect(len(events)).To(Equal(evaluations))
+		for i := range events {
+			Expect(events[i].APIVersion).To(Equal("audit.k8s.io/v1"))
+			Expect(events[i].ObjectRef.Name).To(Equal(chc.Name))
+			Expect(events[i].Annotations["healthcheck.projectsveltos.io/result"]).To(Equal("Healthy"))
+		}
+	})
+
+	It("AuditLogger rotates file when maximum size is reached", func() {
+		auditLogger, err := controllers.NewAuditLogger(auditPath, 1)
+		Expect(err).To(BeNil())
+		defer auditLogger.Close()
+
+		// Each event is a few hundreds bytes long. Add a large annotation so that
+		// few events are enough to reach 1MB
+		event := controllers.GetAuditEvent(randomString(), randomString(), libsveltosv1alpha1.ClusterTypeSveltos, chc,
+			nil, time.Now(), textlogger.NewLogger(textlogger.NewConfig(textlogger.Verbosity(1))))
+		event.Annotations[randomString()] = strings.Repeat("a", 300*1024)
+
+		for i := 0; i < 3; i++ {
+			Expect(auditLogger.Log(event)).To(Succeed())
+		}
+		_, err = os.Stat(auditPath + ".1")
+		Expect(os.IsNotExist(err)).To(BeTrue())
+
+		Expect(auditLogger.Log(event)).To(Succeed())
+
+		Expect(len(readAuditEvents(auditPath + ".1"))).To(Equal(3))
+		Expect(len(readAuditEvents(auditPath))).To(Equal(1))
+	})
+
+	It("AuditLogger keeps logging when rotation fails", func() {
+		auditLogger, err := controllers.NewAuditLogger(auditPath, 1)
+		Expect(err).To(BeNil())
+		defer auditLogger.Close()
+
+		event := controllers.GetAuditEvent(randomString(), randomString(), libsveltosv1alpha1.ClusterTypeSveltos, chc,
+			nil, time.Now(), textlogger.NewLogger(textlogger.NewConfig(textlogger.Verbosity(1))))
+		event.Annotations[randomString()] = strings.Repeat("a", 300*1024)
+
+		for i := 0; i < 3; i++ {
+			Expect(auditLogger.Log(event)).To(Succeed())
+		}
+
+		// A non empty directory where rotated file should go makes rename fail
+		Expect(os.MkdirAll(filepath.Join(auditPath+".1", randomString()), 0700)).To(Succeed())
+
+		Expect(auditLogger.Log(event)).ToNot(Succeed())
+		Expect(auditLogger.Log(event)).ToNot(Succeed())
+		// Events are still written to current file
+		Expect(len(readAuditEvents(auditPath))).To(Equal(5))
+
+		// Once cause is gone, rotation succeeds
+		Expect(os.RemoveAll(auditPath + ".1")).To(Succeed())
+		Expect(auditLogger.Log(event)).To(Succeed())
+		Expect(len(readAuditEvents(auditPath + ".1"))).To(Equal(5))
+		Expect(len(readAuditEvents(auditPath))).To(Equal(1))
+	})
+
+	It("getAuditEvent records pod service account as evaluator", func() {
+		namespace := randomString()
+		serviceAccount := randomString()
+		GinkgoT().Setenv("POD_NAMESPACE", namespace)
+		GinkgoT().Setenv("POD_SERVICE_ACCOUNT", serviceAccount)
+
+		event := controllers.GetAuditEvent(randomString(), randomString(), libsveltosv1alpha1.ClusterTypeSveltos, chc,
+			nil, time.Now(), textlogger.NewLogger(textlogger.NewConfig(textlogger.Verbosity(1))))
+		Expect(event.User.Username).To(Equal("system:serviceaccount:" + namespace + ":" + serviceAccount))
+	})
+})
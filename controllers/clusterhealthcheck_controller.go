@@ -19,9 +19,11 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"runtime/debug"
 	"sync"
 	"time"
 
+	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -34,7 +36,9 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
@@ -70,8 +74,27 @@ const (
 	// normalRequeueAfter is how long to wait before checking again to see if the cluster can be moved
 	// to ready after or workload features (for instance ingress or reporter) have failed
 	normalRequeueAfter = 20 * time.Second
+
+	// invalidationsBufferSize is the number of ClusterHealthChecks which can be queued,
+	// because of a cache invalidation. Once full, InvalidateCacheForCluster drops requeues
+	invalidationsBufferSize = 100
+
+	// panicRequeueAfter is how long to wait before reconciling again a ClusterHealthCheck
+	// whose reconciliation panicked
+	panicRequeueAfter = 30 * time.Second
+
+	// ReconcilePanickedReason is the reason of the event recorded when a reconciliation panics
+	ReconcilePanickedReason = "ReconcilePanicked"
+
+	// Operations a recovered panic is reported for
+	panicOperationReconciliation = "reconciliation"
+	panicOperationEvaluation     = "evaluation"
 )
 
+// EventFilterFunc is invoked for each watch event before any predicate. Returning false
+// drops the event, so it never reaches the reconcile queue.
+type EventFilterFunc func(object client.Object) bool
+
 // ClusterHealthCheckReconciler reconciles a ClusterHealthCheck object
 type ClusterHealthCheckReconciler struct {
 	client.Client
@@ -79,6 +102,26 @@ type ClusterHealthCheckReconciler struct {
 	ConcurrentReconciles int
 	Deployer             deployer.DeployerInterface
 	ShardKey             string // when set, only clusters matching the ShardKey will be reconciled
+
+	// MachineWatchAnnotations is the set of CAPI Machine annotation keys whose changes
+	// trigger a reconciliation
+	MachineWatchAnnotations []string
+
+	// RespawnPausedClusters, when set, makes CAPI Clusters created paused be reconciled on creation as well
+	RespawnPausedClusters bool
+
+	// ClusterWatchConditions is the set of CAPI Cluster condition types whose changes (Status or Reason)
+	// trigger a reconciliation, in addition to changes ClusterPredicate already reacts to
+	ClusterWatchConditions []clusterv1.ConditionType
+
+	// ManagedNamespaces, when set, restricts ClusterSummary events triggering a reconciliation
+	// to ClusterSummaries in those namespaces
+	ManagedNamespaces []string
+
+	// EventFilter, when set, allows custom pre-processing (for instance deduplication)
+	// of events for all watched resources, ClusterHealthChecks and cluster cache invalidations included
+	EventFilter EventFilterFunc
+
 	// use a Mutex to update Map as MaxConcurrentReconciles is higher than one
 	Mux sync.Mutex
 
@@ -128,6 +171,9 @@ type ClusterHealthCheckReconciler struct {
 
 	// Key: ClusterHealthCheck: value: set of HealthChecks referenced
 	CHCToHealthCheckMap map[types.NamespacedName]*libsveltosset.Set
+
+	// invalidations is used to requeue ClusterHealthChecks when cache for a cluster is invalidated
+	invalidations chan event.GenericEvent
 }
 
 //+kubebuilder:rbac:groups=lib.projectsveltos.io,resources=clusterhealthchecks,verbs=get;list;watch;create;update;patch;delete
@@ -146,10 +192,22 @@ type ClusterHealthCheckReconciler struct {
 //+kubebuilder:rbac:groups=lib.projectsveltos.io,resources=healthchecks,verbs=get;watch;list
 //+kubebuilder:rbac:groups=lib.projectsveltos.io,resources=healthcheckreports,verbs=create;update;delete;get;watch;list
 
-func (r *ClusterHealthCheckReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
+func (r *ClusterHealthCheckReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, reterr error) {
 	logger := ctrl.LoggerFrom(ctx)
 	logger.V(logs.LogInfo).Info("Reconciling")
 
+	defer r.recoverFromPanic(req, logger, &result, &reterr)
+
+	ctx, span := startReconcileSpan(ctx, req.Name)
+	defer span.End()
+
+	defer func() {
+		if reterr != nil {
+			trackReconcileError(reterr)
+			span.RecordError(reterr)
+		}
+	}()
+
 	// Fecth the ClusterHealthCheck instance
 	clusterHealthCheck := &libsveltosv1alpha1.ClusterHealthCheck{}
 	if err := r.Get(ctx, req.NamespacedName, clusterHealthCheck); err != nil {
@@ -197,6 +255,42 @@ func (r *ClusterHealthCheckReconciler) Reconcile(ctx context.Context, req ctrl.R
 	return r.reconcileNormal(ctx, clusterHealthCheckScope)
 }
 
+// recoverFromPanic, when deferred in Reconcile, prevents a panic from crashing the controller.
+// Panic and stack trace are logged, a ReconcilePanicked event is recorded for the ClusterHealthCheck
+// and the ClusterHealthCheck is requeued.
+// Returned error is cleared after the reconcile errors metric was updated, so a panic is only
+// counted by the panics metric.
+func (r *ClusterHealthCheckReconciler) recoverFromPanic(req ctrl.Request, logger logr.Logger,
+	result *ctrl.Result, reterr *error) {
+
+	recovered := recover()
+	if recovered == nil {
+		return
+	}
+
+	reportPanic(req.Name, panicOperationReconciliation, recovered, logger)
+
+	*result = ctrl.Result{RequeueAfter: panicRequeueAfter}
+	*reterr = nil
+}
+
+// reportPanic logs panic and stack trace, counts the panic for operation and records a ReconcilePanicked
+// event for the ClusterHealthCheck. Returns the message describing the panic.
+func reportPanic(chcName, operation string, recovered interface{}, logger logr.Logger) string {
+	message := fmt.Sprintf("%s panicked: %v", operation, recovered)
+	logger.Error(errors.Errorf("%v", recovered), fmt.Sprintf("%s panicked", operation),
+		"stacktrace", string(debug.Stack()))
+	reconcilePanics.WithLabelValues(operation).Inc()
+
+	if recorder := getManagementRecorder(); recorder != nil {
+		chc := &libsveltosv1alpha1.ClusterHealthCheck{}
+		chc.Name = chcName
+		recorder.Event(chc, corev1.EventTypeWarning, ReconcilePanickedReason, message)
+	}
+
+	return message
+}
+
 func (r *ClusterHealthCheckReconciler) reconcileDelete(
 	ctx context.Context,
 	clusterHealthCheckScope *scope.ClusterHealthCheckScope,
@@ -208,6 +302,7 @@ func (r *ClusterHealthCheckReconciler) reconcileDelete(
 	clusterHealthCheckScope.SetMatchingClusterRefs(nil)
 
 	r.cleanMaps(clusterHealthCheckScope)
+	resetEvaluationDurations(clusterHealthCheckScope.Name())
 
 	f := getHandlersForFeature(libsveltosv1alpha1.FeatureClusterHealthCheck)
 	err := r.undeployClusterHealthCheck(ctx, clusterHealthCheckScope, f, logger)
@@ -262,6 +357,8 @@ func (r *ClusterHealthCheckReconciler) reconcileNormal(
 	f := getHandlersForFeature(libsveltosv1alpha1.FeatureClusterHealthCheck)
 	if err := r.deployClusterHealthCheck(ctx, clusterHealthCheckScope, f, logger); err != nil {
 		logger.V(logs.LogInfo).Error(err, "failed to deploy")
+		// ClusterHealthCheck is requeued without returning an error. Still count it by category.
+		trackReconcileError(err)
 		return reconcile.Result{Requeue: true, RequeueAfter: normalRequeueAfter}, nil
 	}
 
@@ -271,35 +368,46 @@ func (r *ClusterHealthCheckReconciler) reconcileNormal(
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *ClusterHealthCheckReconciler) SetupWithManager(mgr ctrl.Manager) (controller.Controller, error) {
+	r.invalidations = make(chan event.GenericEvent, invalidationsBufferSize)
+
 	c, err := ctrl.NewControllerManagedBy(mgr).
-		For(&libsveltosv1alpha1.ClusterHealthCheck{}).
+		For(&libsveltosv1alpha1.ClusterHealthCheck{},
+			builder.WithPredicates(getEventFilterPredicate[client.Object](r)),
+		).
 		WithOptions(controller.Options{
 			MaxConcurrentReconciles: r.ConcurrentReconciles,
 		}).
 		Watches(&libsveltosv1alpha1.SveltosCluster{},
 			handler.EnqueueRequestsFromMapFunc(r.requeueClusterHealthCheckForSveltosCluster),
 			builder.WithPredicates(
+				getEventFilterPredicate[client.Object](r),
 				SveltosClusterPredicates(mgr.GetLogger().WithValues("predicate", "sveltosclusterpredicate")),
 			),
 		).
 		Watches(&configv1alpha1.ClusterSummary{},
 			handler.EnqueueRequestsFromMapFunc(r.requeueClusterHealthCheckForClusterSummary),
 			builder.WithPredicates(
-				ClusterSummaryPredicates(mgr.GetLogger().WithValues("predicate", "clustersummarypredicate")),
+				getEventFilterPredicate[client.Object](r),
+				ClusterSummaryPredicates(mgr.GetLogger().WithValues("predicate", "clustersummarypredicate"),
+					r.ManagedNamespaces...),
 			),
 		).
 		Watches(&libsveltosv1alpha1.HealthCheckReport{},
 			handler.EnqueueRequestsFromMapFunc(r.requeueClusterHealthCheckForHealthCheckReport),
 			builder.WithPredicates(
+				getEventFilterPredicate[client.Object](r),
 				HealthCheckReportPredicates(mgr.GetLogger().WithValues("predicate", "healthcheckreportpredicate")),
 			),
 		).
 		Watches(&libsveltosv1alpha1.HealthCheck{},
 			handler.EnqueueRequestsFromMapFunc(r.requeueClusterHealthCheckForHealthCheck),
 			builder.WithPredicates(
+				getEventFilterPredicate[client.Object](r),
 				HealthCheckPredicates(mgr.GetLogger().WithValues("predicate", "healthcheckpredicate")),
 			),
 		).
+		WatchesRawSource(source.Channel(r.invalidations, &handler.EnqueueRequestForObject{},
+			source.WithPredicates[client.Object](getEventFilterPredicate[client.Object](r)))).
 		Build(r)
 	if err != nil {
 		return nil, errors.Wrap(err, "error creating controller")
@@ -311,12 +419,24 @@ func (r *ClusterHealthCheckReconciler) SetupWithManager(mgr ctrl.Manager) (contr
 	return c, nil
 }
 
+// getEventFilterPredicate returns a predicate invoking reconciler EventFilter, if any.
+// It must precede any other predicate so that filtered out events short-circuit.
+func getEventFilterPredicate[T client.Object](r *ClusterHealthCheckReconciler) predicate.TypedFuncs[T] {
+	return predicate.NewTypedPredicateFuncs(func(object T) bool {
+		if r.EventFilter == nil {
+			return true
+		}
+		return r.EventFilter(object)
+	})
+}
+
 func (r *ClusterHealthCheckReconciler) WatchForCAPI(mgr ctrl.Manager, c controller.Controller) error {
 	sourceCluster := source.Kind[*clusterv1.Cluster](
 		mgr.GetCache(),
 		&clusterv1.Cluster{},
 		handler.TypedEnqueueRequestsFromMapFunc(r.requeueClusterHealthCheckForCluster),
-		ClusterPredicate{Logger: mgr.GetLogger().WithValues("predicate", "clusterpredicate")},
+		getEventFilterPredicate[*clusterv1.Cluster](r),
+		r.getClusterPredicate(mgr.GetLogger().WithValues("predicate", "clusterpredicate")),
 	)
 
 	// When cluster-api cluster changes, according to ClusterPredicates,
@@ -329,7 +449,11 @@ func (r *ClusterHealthCheckReconciler) WatchForCAPI(mgr ctrl.Manager, c controll
 		mgr.GetCache(),
 		&clusterv1.Machine{},
 		handler.TypedEnqueueRequestsFromMapFunc(r.requeueClusterHealthCheckForMachine),
-		MachinePredicate{Logger: mgr.GetLogger().WithValues("predicate", "machinepredicate")},
+		getEventFilterPredicate[*clusterv1.Machine](r),
+		MachinePredicate{
+			Logger:              mgr.GetLogger().WithValues("predicate", "machinepredicate"),
+			WatchAnnotationKeys: r.MachineWatchAnnotations,
+		},
 	)
 
 	// When cluster-api machine changes, according to ClusterPredicates,
@@ -341,6 +465,77 @@ func (r *ClusterHealthCheckReconciler) WatchForCAPI(mgr ctrl.Manager, c controll
 	return nil
 }
 
+// getClusterPredicate returns the predicate used for CAPI Cluster events. When ClusterWatchConditions
+// is set, a change to any of those conditions triggers a reconciliation as well.
+func (r *ClusterHealthCheckReconciler) getClusterPredicate(logger logr.Logger) predicate.TypedPredicate[*clusterv1.Cluster] {
+	clusterPredicate := ClusterPredicate{
+		Logger:                logger,
+		RespawnPausedClusters: r.RespawnPausedClusters,
+	}
+
+	if len(r.ClusterWatchConditions) == 0 {
+		return clusterPredicate
+	}
+
+	conditionPredicate := ClusterConditionFilteredPredicates(r.ClusterWatchConditions,
+		logger.WithValues("predicate", "clusterconditionpredicate"))
+
+	return predicate.TypedFuncs[*clusterv1.Cluster]{
+		CreateFunc: clusterPredicate.Create,
+		UpdateFunc: func(e event.TypedUpdateEvent[*clusterv1.Cluster]) bool {
+			return clusterPredicate.Update(e) ||
+				conditionPredicate.Update(event.UpdateEvent{ObjectOld: e.ObjectOld, ObjectNew: e.ObjectNew})
+		},
+		DeleteFunc:  clusterPredicate.Delete,
+		GenericFunc: clusterPredicate.Generic,
+	}
+}
+
+// InvalidateCacheForCluster removes all information cached for the cluster namespace/name
+// (either a SveltosCluster or a CAPI Cluster) and forces a reconciliation of all ClusterHealthChecks
+// matching it. It can be safely invoked by other controllers.
+func (r *ClusterHealthCheckReconciler) InvalidateCacheForCluster(namespace, name string) {
+	clusters := []corev1.ObjectReference{
+		{
+			APIVersion: libsveltosv1alpha1.GroupVersion.String(), Kind: libsveltosv1alpha1.SveltosClusterKind,
+			Namespace: namespace, Name: name,
+		},
+		{
+			APIVersion: clusterv1.GroupVersion.String(), Kind: "Cluster",
+			Namespace: namespace, Name: name,
+		},
+	}
+
+	r.Mux.Lock()
+	consumers := make([]corev1.ObjectReference, 0)
+	for i := range clusters {
+		if v, ok := r.ClusterMap[clusters[i]]; ok {
+			consumers = append(consumers, v.Items()...)
+			delete(r.ClusterMap, clusters[i])
+		}
+		delete(r.ClusterLabels, clusters[i])
+	}
+	r.Mux.Unlock()
+
+	if r.invalidations == nil {
+		// Controller is not started yet. Nothing to requeue.
+		return
+	}
+
+	for i := range consumers {
+		chc := &libsveltosv1alpha1.ClusterHealthCheck{}
+		chc.Name = consumers[i].Name
+		// Never block callers. A dropped ClusterHealthCheck is still reconciled at next cache
+		// resync (SyncPeriod) or on next event.
+		select {
+		case r.invalidations <- event.GenericEvent{Object: chc}:
+		default:
+			ctrl.Log.V(logs.LogInfo).Info(fmt.Sprintf("invalidations queue is full. Dropped requeue of clusterHealthCheck %s "+
+				"for cluster %s/%s", chc.Name, namespace, name))
+		}
+	}
+}
+
 func (r *ClusterHealthCheckReconciler) addFinalizer(ctx context.Context, clusterHealthCheckScope *scope.ClusterHealthCheckScope) error {
 	controllerutil.AddFinalizer(clusterHealthCheckScope.ClusterHealthCheck, libsveltosv1alpha1.ClusterHealthCheckFinalizer)
 	// Register the finalizer immediately to avoid orphaning clusterHealthCheck resources on delete
@@ -388,6 +583,7 @@ func (r *ClusterHealthCheckReconciler) cleanMaps(clusterHealthCheckScope *scope.
 		)
 		if l.Len() == 0 {
 			delete(r.HealthCheckMap, k)
+			resetResourcesEvaluated(k.Name)
 		}
 	}
 
@@ -473,13 +669,18 @@ func (r *ClusterHealthCheckReconciler) updateHealthCheckMaps(clusterHealthCheckS
 	// For each resource not reference anymore, remove ClusterHealthCheck as consumer
 	for i := range toBeRemoved {
 		referencedResource := toBeRemoved[i]
-		r.getReferenceMapForEntry(&referencedResource).Erase(
+		consumers := r.getReferenceMapForEntry(&referencedResource)
+		consumers.Erase(
 			&corev1.ObjectReference{
 				APIVersion: libsveltosv1alpha1.GroupVersion.String(),
 				Kind:       libsveltosv1alpha1.ClusterHealthCheckKind,
 				Name:       clusterHealthCheckScope.Name(),
 			},
 		)
+		if consumers.Len() == 0 {
+			// HealthCheck is not referenced by any ClusterHealthCheck anymore
+			resetResourcesEvaluated(referencedResource.Name)
+		}
 	}
 
 	// Update list of HealthCheck instances currently referenced by ClusterHealthCheck
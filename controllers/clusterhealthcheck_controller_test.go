@@ -19,44 +19,55 @@ package controllers_test
 import (
 	"context"
 	"sync"
+	"time"
 
 	"github.com/go-logr/logr"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/klog/v2/textlogger"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cache/informertest"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 
 	"github.com/projectsveltos/healthcheck-manager/controllers"
+	"github.com/projectsveltos/healthcheck-manager/controllers/testhelpers"
 	"github.com/projectsveltos/healthcheck-manager/pkg/scope"
 	libsveltosv1alpha1 "github.com/projectsveltos/libsveltos/api/v1alpha1"
 	fakedeployer "github.com/projectsveltos/libsveltos/lib/deployer/fake"
 	libsveltosset "github.com/projectsveltos/libsveltos/lib/set"
 )
 
+// panickingClient panics on any Get
+type panickingClient struct {
+	client.Client
+}
+
+func (c *panickingClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	panic("deliberate panic")
+}
+
 func getClusterHealthCheckInstance(name, addonLivenessName string) *libsveltosv1alpha1.ClusterHealthCheck {
 	selector := "bar=foo"
-	return &libsveltosv1alpha1.ClusterHealthCheck{
-		ObjectMeta: metav1.ObjectMeta{
-			Name: name,
-		},
-		Spec: libsveltosv1alpha1.ClusterHealthCheckSpec{
-			ClusterSelector: libsveltosv1alpha1.Selector(selector),
-			LivenessChecks: []libsveltosv1alpha1.LivenessCheck{
-				{
-					Type: libsveltosv1alpha1.LivenessTypeAddons,
-					Name: addonLivenessName,
-				},
-			},
-		},
-	}
+	return testhelpers.NewClusterHealthCheck().
+		WithName(name).
+		WithClusterSelector(selector).
+		WithLivenessCheck(libsveltosv1alpha1.LivenessCheck{
+			Type: libsveltosv1alpha1.LivenessTypeAddons,
+			Name: addonLivenessName,
+		}).
+		Build()
 }
 
 var _ = Describe("ClusterHealthCheck: Reconciler", func() {
@@ -114,12 +125,7 @@ var _ = Describe("ClusterHealthCheck: Reconciler", func() {
 	It("Remove finalizer", func() {
 		Expect(controllerutil.AddFinalizer(chc, libsveltosv1alpha1.ClusterHealthCheckFinalizer)).To(BeTrue())
 
-		cluster := &clusterv1.Cluster{
-			ObjectMeta: metav1.ObjectMeta{
-				Namespace: randomString(),
-				Name:      randomString(),
-			},
-		}
+		cluster := testhelpers.NewCluster().WithNamespace(randomString()).WithName(randomString()).Build()
 
 		Expect(addTypeInformationToObject(scheme, cluster)).To(Succeed())
 
@@ -374,4 +380,296 @@ var _ = Describe("ClusterHealthCheck: Reconciler", func() {
 			Kind: libsveltosv1alpha1.HealthCheckKind, APIVersion: libsveltosv1alpha1.GroupVersion.String()}
 		Expect(controllers.GetReferenceMapForEntry(&reconciler, healthCheckInfo).Len()).To(Equal(1))
 	})
+
+	It("InvalidateCacheForCluster clears cached cluster information and requeues matching ClusterHealthChecks", func() {
+		reconciler := controllers.ClusterHealthCheckReconciler{
+			Scheme:              scheme,
+			Mux:                 sync.Mutex{},
+			ClusterMap:          make(map[corev1.ObjectReference]*libsveltosset.Set),
+			CHCToClusterMap:     make(map[types.NamespacedName]*libsveltosset.Set),
+			ClusterHealthChecks: make(map[corev1.ObjectReference]libsveltosv1alpha1.Selector),
+			ClusterLabels:       make(map[corev1.ObjectReference]map[string]string),
+			HealthCheckMap:      make(map[corev1.ObjectReference]*libsveltosset.Set),
+			CHCToHealthCheckMap: make(map[types.NamespacedName]*libsveltosset.Set),
+		}
+
+		invalidations := make(chan event.GenericEvent, 10)
+		controllers.SetInvalidationsChannel(&reconciler, invalidations)
+
+		clusterNamespace := randomString()
+		clusterName := randomString()
+		clusterInfo := &corev1.ObjectReference{Namespace: clusterNamespace, Name: clusterName,
+			Kind: libsveltosv1alpha1.SveltosClusterKind, APIVersion: libsveltosv1alpha1.GroupVersion.String()}
+		otherClusterInfo := &corev1.ObjectReference{Namespace: clusterNamespace, Name: randomString(),
+			Kind: libsveltosv1alpha1.SveltosClusterKind, APIVersion: libsveltosv1alpha1.GroupVersion.String()}
+
+		chc1 := getClusterHealthCheckInstance(randomString(), randomString())
+		chc2 := getClusterHealthCheckInstance(randomString(), randomString())
+		controllers.GetClusterMapForEntry(&reconciler, clusterInfo).Insert(controllers.GetKeyFromObject(scheme, chc1))
+		controllers.GetClusterMapForEntry(&reconciler, clusterInfo).Insert(controllers.GetKeyFromObject(scheme, chc2))
+		controllers.GetClusterMapForEntry(&reconciler, otherClusterInfo).Insert(controllers.GetKeyFromObject(scheme, chc1))
+		reconciler.ClusterLabels[*clusterInfo] = map[string]string{randomString(): randomString()}
+
+		reconciler.InvalidateCacheForCluster(clusterNamespace, clusterName)
+
+		Expect(reconciler.ClusterMap).ToNot(HaveKey(*clusterInfo))
+		Expect(reconciler.ClusterMap).To(HaveKey(*otherClusterInfo))
+		Expect(reconciler.ClusterLabels).ToNot(HaveKey(*clusterInfo))
+
+		Expect(len(invalidations)).To(Equal(2))
+		requeued := []string{(<-invalidations).Object.GetName(), (<-invalidations).Object.GetName()}
+		Expect(requeued).To(ConsistOf(chc1.Name, chc2.Name))
+
+		// Nothing is cached anymore for this cluster, so nothing is requeued
+		reconciler.InvalidateCacheForCluster(clusterNamespace, clusterName)
+		Expect(len(invalidations)).To(Equal(0))
+	})
+
+	It("InvalidateCacheForCluster does not block when invalidations queue is full", func() {
+		reconciler := controllers.ClusterHealthCheckReconciler{
+			Scheme:              scheme,
+			Mux:                 sync.Mutex{},
+			ClusterMap:          make(map[corev1.ObjectReference]*libsveltosset.Set),
+			CHCToClusterMap:     make(map[types.NamespacedName]*libsveltosset.Set),
+			ClusterHealthChecks: make(map[corev1.ObjectReference]libsveltosv1alpha1.Selector),
+			ClusterLabels:       make(map[corev1.ObjectReference]map[string]string),
+			HealthCheckMap:      make(map[corev1.ObjectReference]*libsveltosset.Set),
+			CHCToHealthCheckMap: make(map[types.NamespacedName]*libsveltosset.Set),
+		}
+
+		invalidations := make(chan event.GenericEvent, 1)
+		controllers.SetInvalidationsChannel(&reconciler, invalidations)
+
+		clusterNamespace := randomString()
+		clusterName := randomString()
+		clusterInfo := &corev1.ObjectReference{Namespace: clusterNamespace, Name: clusterName,
+			Kind: libsveltosv1alpha1.SveltosClusterKind, APIVersion: libsveltosv1alpha1.GroupVersion.String()}
+
+		for i := 0; i < 3; i++ {
+			chc := getClusterHealthCheckInstance(randomString(), randomString())
+			controllers.GetClusterMapForEntry(&reconciler, clusterInfo).Insert(controllers.GetKeyFromObject(scheme, chc))
+		}
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			reconciler.InvalidateCacheForCluster(clusterNamespace, clusterName)
+		}()
+		Eventually(done, time.Second).Should(BeClosed())
+
+		Expect(len(invalidations)).To(Equal(1))
+		Expect(reconciler.ClusterMap).ToNot(HaveKey(*clusterInfo))
+	})
+
+	It("EventFilter, when set, drops events before any predicate", func() {
+		blockedNamespace := randomString()
+
+		reconciler := &controllers.ClusterHealthCheckReconciler{
+			EventFilter: func(object client.Object) bool {
+				return object.GetNamespace() != blockedNamespace
+			},
+		}
+
+		blocked := testhelpers.NewSveltosCluster().WithNamespace(blockedNamespace).WithName(randomString()).Build()
+		allowed := testhelpers.NewSveltosCluster().WithNamespace(randomString()).WithName(randomString()).Build()
+
+		filter := controllers.GetEventFilterPredicate(reconciler)
+		Expect(filter.Create(event.CreateEvent{Object: blocked})).To(BeFalse())
+		Expect(filter.Update(event.UpdateEvent{ObjectOld: blocked, ObjectNew: blocked})).To(BeFalse())
+		Expect(filter.Delete(event.DeleteEvent{Object: blocked})).To(BeFalse())
+		Expect(filter.Generic(event.GenericEvent{Object: blocked})).To(BeFalse())
+
+		Expect(filter.Create(event.CreateEvent{Object: allowed})).To(BeTrue())
+		Expect(filter.Update(event.UpdateEvent{ObjectOld: allowed, ObjectNew: allowed})).To(BeTrue())
+		Expect(filter.Delete(event.DeleteEvent{Object: allowed})).To(BeTrue())
+		Expect(filter.Generic(event.GenericEvent{Object: allowed})).To(BeTrue())
+
+		blockedCluster := testhelpers.NewCluster().WithNamespace(blockedNamespace).WithName(randomString()).Build()
+		allowedCluster := testhelpers.NewCluster().WithNamespace(randomString()).WithName(randomString()).Build()
+
+		clusterFilter := controllers.GetClusterEventFilterPredicate(reconciler)
+		Expect(clusterFilter.Create(event.TypedCreateEvent[*clusterv1.Cluster]{Object: blockedCluster})).To(BeFalse())
+		Expect(clusterFilter.Create(event.TypedCreateEvent[*clusterv1.Cluster]{Object: allowedCluster})).To(BeTrue())
+
+		By("Without EventFilter all events go through")
+		reconciler.EventFilter = nil
+		Expect(filter.Create(event.CreateEvent{Object: blocked})).To(BeTrue())
+		Expect(clusterFilter.Create(event.TypedCreateEvent[*clusterv1.Cluster]{Object: blockedCluster})).To(BeTrue())
+	})
+
+	It("SetupWithManager applies EventFilter to ClusterHealthChecks and cluster cache invalidations", func() {
+		var filteredMux sync.Mutex
+		filtered := make(map[string]bool)
+		isFiltered := func(name string) bool {
+			filteredMux.Lock()
+			defer filteredMux.Unlock()
+			return filtered[name]
+		}
+
+		reconciler := &controllers.ClusterHealthCheckReconciler{
+			Scheme:              scheme,
+			Mux:                 sync.Mutex{},
+			ClusterMap:          make(map[corev1.ObjectReference]*libsveltosset.Set),
+			CHCToClusterMap:     make(map[types.NamespacedName]*libsveltosset.Set),
+			ClusterHealthChecks: make(map[corev1.ObjectReference]libsveltosv1alpha1.Selector),
+			ClusterLabels:       make(map[corev1.ObjectReference]map[string]string),
+			HealthCheckMap:      make(map[corev1.ObjectReference]*libsveltosset.Set),
+			CHCToHealthCheckMap: make(map[types.NamespacedName]*libsveltosset.Set),
+			// Drop every event, so that nothing is ever reconciled
+			EventFilter: func(object client.Object) bool {
+				filteredMux.Lock()
+				defer filteredMux.Unlock()
+				filtered[object.GetName()] = true
+				return false
+			},
+		}
+
+		fakeCache := &informertest.FakeInformers{Scheme: scheme}
+		mgr, err := ctrl.NewManager(&rest.Config{Host: "https://127.0.0.1:6443"}, ctrl.Options{
+			Scheme: scheme,
+			NewCache: func(_ *rest.Config, _ cache.Options) (cache.Cache, error) {
+				return fakeCache, nil
+			},
+			Metrics: metricsserver.Options{BindAddress: "0"},
+		})
+		Expect(err).To(BeNil())
+
+		c, err := reconciler.SetupWithManager(mgr)
+		Expect(err).To(BeNil())
+
+		controllerCtx, controllerCancel := context.WithCancel(context.TODO())
+		defer controllerCancel()
+		go func() {
+			_ = c.Start(controllerCtx)
+		}()
+
+		By("ClusterHealthCheck events")
+		chc := testhelpers.NewClusterHealthCheck().WithName(randomString()).Build()
+		informer, err := fakeCache.FakeInformerFor(controllerCtx, &libsveltosv1alpha1.ClusterHealthCheck{})
+		Expect(err).To(BeNil())
+		// Event handler is registered asynchronously once controller starts
+		Eventually(func() bool {
+			informer.Add(chc)
+			return isFiltered(chc.Name)
+		}, time.Minute, time.Second).Should(BeTrue())
+
+		By("cluster cache invalidations")
+		clusterNamespace := randomString()
+		clusterName := randomString()
+		clusterInfo := &corev1.ObjectReference{Namespace: clusterNamespace, Name: clusterName,
+			Kind: libsveltosv1alpha1.SveltosClusterKind, APIVersion: libsveltosv1alpha1.GroupVersion.String()}
+
+		consumer := getClusterHealthCheckInstance(randomString(), randomString())
+		reconciler.Mux.Lock()
+		controllers.GetClusterMapForEntry(reconciler, clusterInfo).Insert(controllers.GetKeyFromObject(scheme, consumer))
+		reconciler.Mux.Unlock()
+
+		reconciler.InvalidateCacheForCluster(clusterNamespace, clusterName)
+		Eventually(func() bool {
+			return isFiltered(consumer.Name)
+		}, time.Minute, time.Second).Should(BeTrue())
+	})
+
+	It("getClusterPredicate reacts to ClusterWatchConditions changes only when configured", func() {
+		oldCluster := testhelpers.NewCluster().WithNamespace(randomString()).WithName(randomString()).Build()
+		newCluster := oldCluster.DeepCopy()
+		newCluster.Status.Conditions = []clusterv1.Condition{
+			{Type: clusterv1.InfrastructureReadyCondition, Status: corev1.ConditionTrue},
+		}
+		updateEvent := event.TypedUpdateEvent[*clusterv1.Cluster]{ObjectOld: oldCluster, ObjectNew: newCluster}
+
+		reconciler := &controllers.ClusterHealthCheckReconciler{}
+		Expect(controllers.GetClusterPredicate(reconciler, logger).Update(updateEvent)).To(BeFalse())
+
+		reconciler.ClusterWatchConditions = []clusterv1.ConditionType{clusterv1.ControlPlaneReadyCondition}
+		Expect(controllers.GetClusterPredicate(reconciler, logger).Update(updateEvent)).To(BeFalse())
+
+		reconciler.ClusterWatchConditions = []clusterv1.ConditionType{clusterv1.InfrastructureReadyCondition}
+		Expect(controllers.GetClusterPredicate(reconciler, logger).Update(updateEvent)).To(BeTrue())
+
+		// Create keeps ClusterPredicate behavior
+		pausedCluster := testhelpers.NewCluster().WithNamespace(randomString()).WithName(randomString()).
+			WithPaused(true).Build()
+		createEvent := event.TypedCreateEvent[*clusterv1.Cluster]{Object: pausedCluster}
+		Expect(controllers.GetClusterPredicate(reconciler, logger).Create(createEvent)).To(BeFalse())
+
+		reconciler.RespawnPausedClusters = true
+		Expect(controllers.GetClusterPredicate(reconciler, logger).Create(createEvent)).To(BeTrue())
+	})
+
+	It("Reconcile counts cluster_not_found errors when a matching cluster cannot be fetched", func() {
+		sveltosCluster := testhelpers.NewSveltosCluster().
+			WithNamespace(randomString()).
+			WithName(randomString()).
+			WithLabels(map[string]string{"bar": "foo"}).
+			WithReady(true).
+			Build()
+
+		initObjects := []client.Object{
+			chc, sveltosCluster,
+		}
+
+		// Cluster is listed as matching, but it is gone by the time it is fetched
+		c := fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(initObjects...).
+			WithObjects(initObjects...).
+			WithInterceptorFuncs(interceptor.Funcs{
+				Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object,
+					opts ...client.GetOption) error {
+
+					if _, ok := obj.(*libsveltosv1alpha1.SveltosCluster); ok {
+						return apierrors.NewNotFound(libsveltosv1alpha1.GroupVersion.WithResource("sveltosclusters").GroupResource(),
+							key.Name)
+					}
+					return c.Get(ctx, key, obj, opts...)
+				},
+			}).Build()
+
+		dep := fakedeployer.GetClient(context.TODO(), logger, c)
+		controllers.RegisterFeatures(dep, logger)
+
+		reconciler := controllers.ClusterHealthCheckReconciler{
+			Client:              c,
+			Deployer:            dep,
+			Scheme:              c.Scheme(),
+			Mux:                 sync.Mutex{},
+			ClusterMap:          make(map[corev1.ObjectReference]*libsveltosset.Set),
+			CHCToClusterMap:     make(map[types.NamespacedName]*libsveltosset.Set),
+			ClusterHealthChecks: make(map[corev1.ObjectReference]libsveltosv1alpha1.Selector),
+			HealthCheckMap:      make(map[corev1.ObjectReference]*libsveltosset.Set),
+			CHCToHealthCheckMap: make(map[types.NamespacedName]*libsveltosset.Set),
+		}
+
+		before := controllers.GetReconcileErrors("cluster_not_found")
+
+		_, err := reconciler.Reconcile(context.TODO(), ctrl.Request{
+			NamespacedName: types.NamespacedName{Name: chc.Name},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(controllers.GetReconcileErrors("cluster_not_found")).To(Equal(before + 1))
+	})
+
+	It("Reconcile recovers from panics, records ReconcilePanicked event and requeues", func() {
+		recorder := record.NewFakeRecorder(1)
+		controllers.SetManagementRecorder(recorder)
+		defer controllers.SetManagementRecorder(nil)
+
+		reconciler := &controllers.ClusterHealthCheckReconciler{
+			Client: &panickingClient{},
+			Scheme: scheme,
+		}
+
+		panics := controllers.GetReconcilePanics("reconciliation")
+
+		result, err := reconciler.Reconcile(context.TODO(), ctrl.Request{
+			NamespacedName: types.NamespacedName{Name: randomString()},
+		})
+		Expect(err).To(BeNil())
+		Expect(result.RequeueAfter).To(Equal(30 * time.Second))
+		Expect(controllers.GetReconcilePanics("reconciliation")).To(Equal(panics + 1))
+
+		Expect(recorder.Events).To(HaveLen(1))
+		recorded := <-recorder.Events
+		Expect(recorded).To(ContainSubstring(controllers.ReconcilePanickedReason))
+		Expect(recorded).To(ContainSubstring("deliberate panic"))
+	})
 })
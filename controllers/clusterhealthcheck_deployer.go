@@ -26,6 +26,7 @@ import (
 
 	"github.com/gdexlab/go-render/render"
 	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -47,6 +48,13 @@ import (
 const (
 	// Namespace where reports will be generated
 	ReportNamespace = "projectsveltos"
+
+	// SkipClusterAnnotation, when set to "true" on a SveltosCluster or ClusterAPI Cluster,
+	// opts that cluster out of health checking
+	SkipClusterAnnotation = "healthcheck.sveltos.io/skip"
+
+	// ClusterOptedOutCondition is the condition reported for clusters opted out of health checking
+	ClusterOptedOutCondition = "ClusterOptedOut"
 )
 
 type getCurrentHash func(tx context.Context, c client.Client,
@@ -409,7 +417,7 @@ func (r *ClusterHealthCheckReconciler) canProceed(ctx context.Context, chcScope
 
 	ready, err := clusterproxy.IsClusterReadyToBeConfigured(ctx, r.Client, cluster, chcScope.Logger)
 	if err != nil {
-		return false, err
+		return false, wrapClusterError(err, cluster.Namespace, cluster.Name, clusterproxy.GetClusterType(cluster))
 	}
 
 	if !ready {
@@ -523,13 +531,21 @@ func fetchHealthCheck(ctx context.Context, c client.Client, ref *corev1.ObjectRe
 // look at resources directly in managed cluster);
 func processClusterHealthCheckForCluster(ctx context.Context, c client.Client,
 	clusterNamespace, clusterName, applicant, featureID string,
-	clusterType libsveltosv1alpha1.ClusterType, options deployer.Options, logger logr.Logger) error {
+	clusterType libsveltosv1alpha1.ClusterType, options deployer.Options, logger logr.Logger) (err error) {
 
 	logger = logger.WithValues("clusterhealthcheck", applicant)
 	logger = logger.WithValues("cluster", fmt.Sprintf("%s:%s/%s", clusterType, clusterNamespace, clusterName))
 
+	// Deployer workers do not recover from panics. Turn a panic, for instance while evaluating
+	// health checks, into an error so the whole manager does not crash.
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = errors.New(reportPanic(applicant, panicOperationEvaluation, recovered, logger))
+		}
+	}()
+
 	chc := &libsveltosv1alpha1.ClusterHealthCheck{}
-	err := c.Get(ctx, types.NamespacedName{Name: applicant}, chc)
+	err = c.Get(ctx, types.NamespacedName{Name: applicant}, chc)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
 			logger.V(logs.LogDebug).Info("clusterHealthCheck not found")
@@ -543,6 +559,17 @@ func processClusterHealthCheckForCluster(ctx context.Context, c client.Client,
 		return nil
 	}
 
+	// Opted out clusters are never contacted: no HealthCheck is deployed there, nor evaluated
+	optedOut, err := isClusterOptedOut(ctx, c, clusterNamespace, clusterName, clusterType)
+	if err != nil {
+		logger.V(logs.LogInfo).Info(fmt.Sprintf("failed to verify whether cluster opted out: %v", err))
+		return err
+	}
+	if optedOut {
+		logger.V(logs.LogDebug).Info("cluster opted out of health checking. Skip it")
+		return processOptedOutCluster(ctx, c, clusterNamespace, clusterName, clusterType, chc, logger)
+	}
+
 	logger.V(logs.LogDebug).Info("Deploy clusterHealthCheck")
 
 	err = deployHealthChecks(ctx, c, clusterNamespace, clusterName, clusterType, chc, logger)
@@ -564,6 +591,7 @@ func processClusterHealthCheckForCluster(ctx context.Context, c client.Client,
 
 // evaluateHealthChecksAndSendNotificationsForCluster does following:
 // - evaluate all health checks (updating ClusterHealthCheck Status)
+// - publish evaluation results, if a publisher is configured
 // - send notifications
 func evaluateHealthChecksAndSendNotificationsForCluster(ctx context.Context, c client.Client,
 	clusterNamespace, clusterName string, clusterType libsveltosv1alpha1.ClusterType,
@@ -571,11 +599,14 @@ func evaluateHealthChecksAndSendNotificationsForCluster(ctx context.Context, c c
 
 	logger.V(logs.LogDebug).Info("Evaluate health checks and send Notifications for clusterHealthCheck")
 
+	start := time.Now()
 	conditions, changed, err := evaluateClusterHealthCheckForCluster(ctx, c, clusterNamespace, clusterName, clusterType, chc, logger)
 	if err != nil {
 		logger.V(logs.LogInfo).Info(fmt.Sprintf("failed to evaluate livenessChecks: %v", err))
 		return err
 	}
+	averageDuration := recordEvaluationDuration(chc.Name, clusterNamespace, clusterName, clusterType, time.Since(start))
+	logger.V(logs.LogDebug).Info(fmt.Sprintf("average evaluation duration %.2fms", averageDuration))
 
 	err = updateConditionsForCluster(ctx, c, clusterNamespace, clusterName, clusterType, chc, conditions, logger)
 	if err != nil {
@@ -583,9 +614,93 @@ func evaluateHealthChecksAndSendNotificationsForCluster(ctx context.Context, c c
 		return err
 	}
 
+	publishEvaluation(ctx, clusterNamespace, clusterName, clusterType, chc, conditions, logger)
+	publishHealthTransition(ctx, clusterNamespace, clusterName, clusterType, chc, conditions, changed, logger)
+	recordClusterHealthStatus(clusterNamespace, clusterName, clusterType, chc, conditions)
+	auditEvaluation(clusterNamespace, clusterName, clusterType, chc, conditions, logger)
+
 	return sendNotifications(ctx, c, clusterNamespace, clusterName, clusterType, chc, changed, conditions, logger)
 }
 
+// isClusterOptedOut returns true if cluster has SkipClusterAnnotation set to "true"
+func isClusterOptedOut(ctx context.Context, c client.Client,
+	clusterNamespace, clusterName string, clusterType libsveltosv1alpha1.ClusterType) (bool, error) {
+
+	cluster, err := clusterproxy.GetCluster(ctx, c, clusterNamespace, clusterName, clusterType)
+	if err != nil {
+		return false, wrapClusterError(err, clusterNamespace, clusterName, clusterType)
+	}
+
+	return cluster.GetAnnotations()[SkipClusterAnnotation] == "true", nil
+}
+
+// processOptedOutCluster forgets evaluation results previously recorded for a cluster which
+// opted out of health checking and reports it as opted out in ClusterHealthCheck Status
+func processOptedOutCluster(ctx context.Context, c client.Client,
+	clusterNamespace, clusterName string, clusterType libsveltosv1alpha1.ClusterType,
+	chc *libsveltosv1alpha1.ClusterHealthCheck, logger logr.Logger) error {
+
+	forgetClusterEvaluation(clusterNamespace, clusterName, clusterType, chc.Name)
+	removeResourcesEvaluatedForCluster(clusterNamespace, clusterName, clusterType, chc)
+
+	return updateConditionsForCluster(ctx, c, clusterNamespace, clusterName, clusterType, chc,
+		getClusterOptedOutConditions(chc, clusterNamespace, clusterName, clusterType), logger)
+}
+
+// getClusterOptedOutConditions returns the conditions reported, in place of LivenessCheck
+// evaluations, for a cluster opted out of health checking.
+// Status is Unknown as cluster health is not evaluated. LastTransitionTime is preserved if
+// the cluster was already reported as opted out.
+func getClusterOptedOutConditions(chc *libsveltosv1alpha1.ClusterHealthCheck,
+	clusterNamespace, clusterName string, clusterType libsveltosv1alpha1.ClusterType) []libsveltosv1alpha1.Condition {
+
+	condition := libsveltosv1alpha1.Condition{
+		Type:               libsveltosv1alpha1.ConditionType(ClusterOptedOutCondition),
+		Name:               ClusterOptedOutCondition,
+		Status:             corev1.ConditionUnknown,
+		Severity:           libsveltosv1alpha1.ConditionSeverityInfo,
+		Message:            fmt.Sprintf("cluster opted out of health checking via %s annotation", SkipClusterAnnotation),
+		Reason:             ClusterOptedOutCondition,
+		LastTransitionTime: metav1.Time{Time: time.Now()},
+	}
+
+	for i := range chc.Status.ClusterConditions {
+		cc := &chc.Status.ClusterConditions[i]
+		if !isClusterConditionForCluster(cc, clusterNamespace, clusterName, clusterType) {
+			continue
+		}
+		for j := range cc.Conditions {
+			if cc.Conditions[j].Type == condition.Type && cc.Conditions[j].Status == condition.Status {
+				condition.LastTransitionTime = cc.Conditions[j].LastTransitionTime
+			}
+		}
+	}
+
+	return []libsveltosv1alpha1.Condition{condition}
+}
+
+// forgetClusterEvaluation removes in-memory evaluation results recorded for a ClusterHealthCheck/cluster pair
+func forgetClusterEvaluation(clusterNamespace, clusterName string, clusterType libsveltosv1alpha1.ClusterType,
+	chcName string) {
+
+	removeClusterHealthStatus(chcName, clusterNamespace, clusterName, clusterType)
+	removeEvaluationDuration(chcName, clusterNamespace, clusterName, clusterType)
+}
+
+// removeResourcesEvaluatedForCluster removes, for a cluster, resources evaluated metric of all HealthChecks
+// referenced by ClusterHealthCheck
+func removeResourcesEvaluatedForCluster(clusterNamespace, clusterName string, clusterType libsveltosv1alpha1.ClusterType,
+	chc *libsveltosv1alpha1.ClusterHealthCheck) {
+
+	for i := range chc.Spec.LivenessChecks {
+		livenessCheck := &chc.Spec.LivenessChecks[i]
+		if livenessCheck.LivenessSourceRef == nil {
+			continue
+		}
+		removeResourcesEvaluated(clusterNamespace, clusterName, clusterType, livenessCheck.LivenessSourceRef.Name)
+	}
+}
+
 // undeployClusterHealthCheckResourcesFromCluster cleans resources associtated with ClusterHealthCheck instance from cluster
 func undeployClusterHealthCheckResourcesFromCluster(ctx context.Context, c client.Client,
 	clusterNamespace, clusterName, applicant, featureID string,
@@ -593,6 +708,8 @@ func undeployClusterHealthCheckResourcesFromCluster(ctx context.Context, c clien
 
 	logger = logger.WithValues("clusterhealthcheck", applicant)
 
+	forgetClusterEvaluation(clusterNamespace, clusterName, clusterType, applicant)
+
 	chc := &libsveltosv1alpha1.ClusterHealthCheck{}
 	err := c.Get(ctx, types.NamespacedName{Name: applicant}, chc)
 	if err != nil {
@@ -603,6 +720,8 @@ func undeployClusterHealthCheckResourcesFromCluster(ctx context.Context, c clien
 		return err
 	}
 
+	removeResourcesEvaluatedForCluster(clusterNamespace, clusterName, clusterType, chc)
+
 	logger = logger.WithValues("cluster", fmt.Sprintf("%s:%s/%s", clusterType, clusterNamespace, clusterName))
 	logger.V(logs.LogDebug).Info("Undeploy clusterHealthCheck")
 
@@ -641,7 +760,7 @@ func evaluateClusterHealthCheckForCluster(ctx context.Context, c client.Client,
 			&livenessCheck, logger)
 		if err != nil {
 			logger.V(logs.LogDebug).Info("failed to evaluate livenessCheck %v. Err: %v", livenessCheck, err)
-			return nil, false, err
+			return nil, false, wrapRemoteError(err)
 		}
 		if tmpStatusChanged {
 			statusChanged = true
@@ -836,14 +955,14 @@ func removeStaleHealthChecks(ctx context.Context, c client.Client,
 		"", "", clusterType, logger)
 	if err != nil {
 		logger.V(logs.LogInfo).Info(fmt.Sprintf("failed to get managed cluster client: %v", err))
-		return err
+		return wrapRemoteError(err)
 	}
 
 	healthCheckList := &libsveltosv1alpha1.HealthCheckList{}
 	err = remoteClient.List(ctx, healthCheckList)
 	if err != nil {
 		logger.V(logs.LogInfo).Info(fmt.Sprintf("failed to get list HealthChecks: %v", err))
-		return err
+		return wrapRemoteError(err)
 	}
 
 	// Create a map (for faster indexing) of the HealthChecks currently referenced
@@ -874,7 +993,7 @@ func removeStaleHealthChecks(ctx context.Context, c client.Client,
 			err = remoteClient.Update(ctx, hc)
 			if err != nil {
 				logger.V(logs.LogInfo).Info(fmt.Sprintf("failed to get update HealthCheck: %v", err))
-				return err
+				return wrapRemoteError(err)
 			}
 			continue
 		}
@@ -882,7 +1001,7 @@ func removeStaleHealthChecks(ctx context.Context, c client.Client,
 		err = remoteClient.Delete(ctx, hc)
 		if err != nil {
 			logger.V(logs.LogInfo).Info(fmt.Sprintf("failed to get delete HealthCheck: %v", err))
-			return err
+			return wrapRemoteError(err)
 		}
 	}
 
@@ -904,7 +1023,7 @@ func deployHealthChecks(ctx context.Context, c client.Client,
 		"", "", clusterType, logger)
 	if err != nil {
 		logger.V(logs.LogInfo).Info(fmt.Sprintf("failed to get managed cluster client: %v", err))
-		return err
+		return wrapRemoteError(err)
 	}
 
 	// classifier installs sveltos-agent and CRDs it needs, including
@@ -915,7 +1034,7 @@ func deployHealthChecks(ctx context.Context, c client.Client,
 		err = deployHealthCheck(ctx, c, remoteClient, chc, &lc, logger)
 		if err != nil {
 			logger.V(logs.LogInfo).Info(fmt.Sprintf("failed to get deploy healthCheck: %v", err))
-			return err
+			return wrapRemoteError(err)
 		}
 	}
 
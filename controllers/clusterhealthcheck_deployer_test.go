@@ -37,6 +37,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	"github.com/projectsveltos/healthcheck-manager/controllers"
+	"github.com/projectsveltos/healthcheck-manager/controllers/testhelpers"
 	"github.com/projectsveltos/healthcheck-manager/pkg/scope"
 	libsveltosv1alpha1 "github.com/projectsveltos/libsveltos/api/v1alpha1"
 	"github.com/projectsveltos/libsveltos/lib/deployer"
@@ -60,19 +61,15 @@ var _ = Describe("ClusterHealthCheck deployer", func() {
 		clusterName := randomString()
 		clusterType := libsveltosv1alpha1.ClusterTypeCapi
 
-		chc := &libsveltosv1alpha1.ClusterHealthCheck{
-			ObjectMeta: metav1.ObjectMeta{
-				Name: randomString(),
-			},
-			Status: libsveltosv1alpha1.ClusterHealthCheckStatus{
-				ClusterConditions: []libsveltosv1alpha1.ClusterCondition{
-					*getClusterCondition(clusterNamespace, clusterName, clusterType),
-					*getClusterCondition(clusterNamespace, randomString(), clusterType),
-					*getClusterCondition(randomString(), clusterName, clusterType),
-					*getClusterCondition(clusterNamespace, clusterName, libsveltosv1alpha1.ClusterTypeSveltos),
-				},
-			},
-		}
+		chc := testhelpers.NewClusterHealthCheck().
+			WithName(randomString()).
+			WithClusterConditions(
+				*getClusterCondition(clusterNamespace, clusterName, clusterType),
+				*getClusterCondition(clusterNamespace, randomString(), clusterType),
+				*getClusterCondition(randomString(), clusterName, clusterType),
+				*getClusterCondition(clusterNamespace, clusterName, libsveltosv1alpha1.ClusterTypeSveltos),
+			).
+			Build()
 
 		initObjects := []client.Object{
 			chc,
@@ -97,26 +94,17 @@ var _ = Describe("ClusterHealthCheck deployer", func() {
 		clusterName := randomString()
 		clusterType := libsveltosv1alpha1.ClusterTypeCapi
 
-		cluster := &clusterv1.Cluster{
-			ObjectMeta: metav1.ObjectMeta{
-				Namespace: clusterNamespace,
-				Name:      clusterName,
-			},
-		}
+		cluster := testhelpers.NewCluster().WithNamespace(clusterNamespace).WithName(clusterName).Build()
 
-		chc := &libsveltosv1alpha1.ClusterHealthCheck{
-			ObjectMeta: metav1.ObjectMeta{
-				Name: randomString(),
-			},
-			Status: libsveltosv1alpha1.ClusterHealthCheckStatus{
-				ClusterConditions: []libsveltosv1alpha1.ClusterCondition{
-					*getClusterCondition(clusterNamespace, clusterName, clusterType),
-					*getClusterCondition(clusterNamespace, randomString(), clusterType),
-					*getClusterCondition(randomString(), clusterName, clusterType),
-					*getClusterCondition(clusterNamespace, clusterName, libsveltosv1alpha1.ClusterTypeSveltos),
-				},
-			},
-		}
+		chc := testhelpers.NewClusterHealthCheck().
+			WithName(randomString()).
+			WithClusterConditions(
+				*getClusterCondition(clusterNamespace, clusterName, clusterType),
+				*getClusterCondition(clusterNamespace, randomString(), clusterType),
+				*getClusterCondition(randomString(), clusterName, clusterType),
+				*getClusterCondition(clusterNamespace, clusterName, libsveltosv1alpha1.ClusterTypeSveltos),
+			).
+			Build()
 
 		initObjects := []client.Object{
 			chc, cluster,
@@ -161,26 +149,17 @@ var _ = Describe("ClusterHealthCheck deployer", func() {
 		clusterName := randomString()
 		clusterType := libsveltosv1alpha1.ClusterTypeCapi
 
-		cluster := &clusterv1.Cluster{
-			ObjectMeta: metav1.ObjectMeta{
-				Namespace: clusterNamespace,
-				Name:      clusterName,
-			},
-		}
+		cluster := testhelpers.NewCluster().WithNamespace(clusterNamespace).WithName(clusterName).Build()
 
-		chc := &libsveltosv1alpha1.ClusterHealthCheck{
-			ObjectMeta: metav1.ObjectMeta{
-				Name: randomString(),
-			},
-			Status: libsveltosv1alpha1.ClusterHealthCheckStatus{
-				ClusterConditions: []libsveltosv1alpha1.ClusterCondition{
-					*getClusterCondition(clusterNamespace, clusterName, clusterType),
-					*getClusterCondition(clusterNamespace, randomString(), clusterType),
-					*getClusterCondition(randomString(), clusterName, clusterType),
-					*getClusterCondition(clusterNamespace, clusterName, libsveltosv1alpha1.ClusterTypeSveltos),
-				},
-			},
-		}
+		chc := testhelpers.NewClusterHealthCheck().
+			WithName(randomString()).
+			WithClusterConditions(
+				*getClusterCondition(clusterNamespace, clusterName, clusterType),
+				*getClusterCondition(clusterNamespace, randomString(), clusterType),
+				*getClusterCondition(randomString(), clusterName, clusterType),
+				*getClusterCondition(clusterNamespace, clusterName, libsveltosv1alpha1.ClusterTypeSveltos),
+			).
+			Build()
 
 		initObjects := []client.Object{
 			chc, cluster,
@@ -254,6 +233,187 @@ var _ = Describe("ClusterHealthCheck deployer", func() {
 		Expect(conditions[0].Type).To(Equal(libsveltosv1alpha1.ConditionType(controllers.GetConditionType(&livenessCheck))))
 	})
 
+	It("isClusterOptedOut returns true only for clusters with skip annotation", func() {
+		clusterNamespace := randomString()
+		optedOut := map[string]string{controllers.SkipClusterAnnotation: "true"}
+
+		annotatedSveltosCluster := testhelpers.NewSveltosCluster().WithNamespace(clusterNamespace).
+			WithName(randomString()).WithAnnotations(optedOut).Build()
+		sveltosCluster := testhelpers.NewSveltosCluster().WithNamespace(clusterNamespace).
+			WithName(randomString()).Build()
+		annotatedCluster := testhelpers.NewCluster().WithNamespace(clusterNamespace).
+			WithName(randomString()).WithAnnotations(optedOut).Build()
+		cluster := testhelpers.NewCluster().WithNamespace(clusterNamespace).
+			WithName(randomString()).WithAnnotations(map[string]string{controllers.SkipClusterAnnotation: "false"}).Build()
+
+		initObjects := []client.Object{
+			annotatedSveltosCluster, sveltosCluster, annotatedCluster, cluster,
+		}
+
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(initObjects...).Build()
+
+		skip, err := controllers.IsClusterOptedOut(context.TODO(), c, clusterNamespace, annotatedSveltosCluster.Name,
+			libsveltosv1alpha1.ClusterTypeSveltos)
+		Expect(err).To(BeNil())
+		Expect(skip).To(BeTrue())
+
+		skip, err = controllers.IsClusterOptedOut(context.TODO(), c, clusterNamespace, sveltosCluster.Name,
+			libsveltosv1alpha1.ClusterTypeSveltos)
+		Expect(err).To(BeNil())
+		Expect(skip).To(BeFalse())
+
+		skip, err = controllers.IsClusterOptedOut(context.TODO(), c, clusterNamespace, annotatedCluster.Name,
+			libsveltosv1alpha1.ClusterTypeCapi)
+		Expect(err).To(BeNil())
+		Expect(skip).To(BeTrue())
+
+		skip, err = controllers.IsClusterOptedOut(context.TODO(), c, clusterNamespace, cluster.Name,
+			libsveltosv1alpha1.ClusterTypeCapi)
+		Expect(err).To(BeNil())
+		Expect(skip).To(BeFalse())
+	})
+
+	It("processClusterHealthCheckForCluster reports ClusterOptedOut for annotated clusters", func() {
+		clusterNamespace := randomString()
+		clusterName := randomString()
+		clusterType := libsveltosv1alpha1.ClusterTypeSveltos
+
+		sveltosCluster := testhelpers.NewSveltosCluster().WithNamespace(clusterNamespace).WithName(clusterName).
+			WithAnnotations(map[string]string{controllers.SkipClusterAnnotation: "true"}).Build()
+
+		chc := testhelpers.NewClusterHealthCheck().
+			WithName(randomString()).
+			WithLivenessCheck(libsveltosv1alpha1.LivenessCheck{
+				Name: randomString(),
+				Type: libsveltosv1alpha1.LivenessTypeAddons,
+			}).
+			WithClusterConditions(*getClusterCondition(clusterNamespace, clusterName, clusterType)).
+			Build()
+
+		initObjects := []client.Object{
+			chc, sveltosCluster,
+		}
+
+		c := fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(initObjects...).
+			WithObjects(initObjects...).Build()
+
+		Expect(controllers.ProcessClusterHealthCheckForCluster(context.TODO(), c, clusterNamespace, clusterName,
+			chc.Name, libsveltosv1alpha1.FeatureClusterHealthCheck, clusterType, deployer.Options{}, logger)).To(Succeed())
+
+		currentChc := &libsveltosv1alpha1.ClusterHealthCheck{}
+		Expect(c.Get(context.TODO(), types.NamespacedName{Name: chc.Name}, currentChc)).To(Succeed())
+		Expect(len(currentChc.Status.ClusterConditions)).To(Equal(1))
+		conditions := currentChc.Status.ClusterConditions[0].Conditions
+		Expect(len(conditions)).To(Equal(1))
+		Expect(conditions[0].Type).To(Equal(libsveltosv1alpha1.ConditionType(controllers.ClusterOptedOutCondition)))
+		// Opted out clusters must not be reported as healthy
+		Expect(conditions[0].Status).To(Equal(corev1.ConditionUnknown))
+	})
+
+	It("processClusterHealthCheckForCluster preserves ClusterOptedOut LastTransitionTime", func() {
+		clusterNamespace := randomString()
+		clusterName := randomString()
+		clusterType := libsveltosv1alpha1.ClusterTypeSveltos
+
+		sveltosCluster := testhelpers.NewSveltosCluster().WithNamespace(clusterNamespace).WithName(clusterName).
+			WithAnnotations(map[string]string{controllers.SkipClusterAnnotation: "true"}).Build()
+
+		lastTransitionTime := metav1.NewTime(time.Now().Add(-time.Hour).Truncate(time.Second))
+		clusterCondition := getClusterCondition(clusterNamespace, clusterName, clusterType)
+		clusterCondition.Conditions = []libsveltosv1alpha1.Condition{
+			{
+				Type:               libsveltosv1alpha1.ConditionType(controllers.ClusterOptedOutCondition),
+				Name:               controllers.ClusterOptedOutCondition,
+				Status:             corev1.ConditionUnknown,
+				LastTransitionTime: lastTransitionTime,
+			},
+		}
+
+		chc := testhelpers.NewClusterHealthCheck().
+			WithName(randomString()).
+			WithLivenessCheck(libsveltosv1alpha1.LivenessCheck{
+				Name: randomString(),
+				Type: libsveltosv1alpha1.LivenessTypeAddons,
+			}).
+			WithClusterConditions(*clusterCondition).
+			Build()
+
+		initObjects := []client.Object{
+			chc, sveltosCluster,
+		}
+
+		c := fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(initObjects...).
+			WithObjects(initObjects...).Build()
+
+		Expect(controllers.ProcessClusterHealthCheckForCluster(context.TODO(), c, clusterNamespace, clusterName,
+			chc.Name, libsveltosv1alpha1.FeatureClusterHealthCheck, clusterType, deployer.Options{}, logger)).To(Succeed())
+
+		currentChc := &libsveltosv1alpha1.ClusterHealthCheck{}
+		Expect(c.Get(context.TODO(), types.NamespacedName{Name: chc.Name}, currentChc)).To(Succeed())
+		Expect(len(currentChc.Status.ClusterConditions)).To(Equal(1))
+		conditions := currentChc.Status.ClusterConditions[0].Conditions
+		Expect(len(conditions)).To(Equal(1))
+		Expect(conditions[0].LastTransitionTime.Time.Equal(lastTransitionTime.Time)).To(BeTrue())
+	})
+
+	It("processClusterHealthCheckForCluster forgets evaluation results of opted out clusters", func() {
+		clusterNamespace := randomString()
+		clusterName := randomString()
+		clusterType := libsveltosv1alpha1.ClusterTypeSveltos
+
+		sveltosCluster := testhelpers.NewSveltosCluster().WithNamespace(clusterNamespace).WithName(clusterName).
+			WithAnnotations(map[string]string{controllers.SkipClusterAnnotation: "true"}).Build()
+
+		healthCheckName := randomString()
+		chc := testhelpers.NewClusterHealthCheck().
+			WithName(randomString()).
+			WithLivenessCheck(libsveltosv1alpha1.LivenessCheck{
+				Name: randomString(),
+				Type: libsveltosv1alpha1.LivenessTypeHealthCheck,
+				LivenessSourceRef: &corev1.ObjectReference{
+					APIVersion: libsveltosv1alpha1.GroupVersion.String(),
+					Kind:       libsveltosv1alpha1.HealthCheckKind,
+					Name:       healthCheckName,
+				},
+			}).
+			WithClusterConditions(*getClusterCondition(clusterNamespace, clusterName, clusterType)).
+			Build()
+
+		initObjects := []client.Object{
+			chc, sveltosCluster,
+		}
+
+		c := fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(initObjects...).
+			WithObjects(initObjects...).Build()
+
+		resourcesEvaluated := controllers.CountResourcesEvaluated()
+		evaluationDurations := controllers.CountEvaluationDurationAverages()
+
+		controllers.RecordClusterHealthStatus(clusterNamespace, clusterName, clusterType, chc, nil)
+		controllers.SetResourcesEvaluated(clusterNamespace, clusterName, clusterType, healthCheckName, 3)
+		controllers.RecordEvaluationDuration(chc.Name, clusterNamespace, clusterName, clusterType, 10*time.Millisecond)
+		defer controllers.RemoveClusterHealthStatus(chc.Name, clusterNamespace, clusterName, clusterType)
+		Expect(controllers.GetClusterHealthStatus(chc.Name, clusterNamespace, clusterName, clusterType)).ToNot(BeNil())
+
+		Expect(controllers.ProcessClusterHealthCheckForCluster(context.TODO(), c, clusterNamespace, clusterName,
+			chc.Name, libsveltosv1alpha1.FeatureClusterHealthCheck, clusterType, deployer.Options{}, logger)).To(Succeed())
+
+		Expect(controllers.GetClusterHealthStatus(chc.Name, clusterNamespace, clusterName, clusterType)).To(BeNil())
+		Expect(controllers.CountResourcesEvaluated()).To(Equal(resourcesEvaluated))
+		Expect(controllers.CountEvaluationDurationAverages()).To(Equal(evaluationDurations))
+	})
+
+	It("processClusterHealthCheckForCluster turns a panic into an error", func() {
+		panics := controllers.GetReconcilePanics("evaluation")
+
+		err := controllers.ProcessClusterHealthCheckForCluster(context.TODO(), &panickingClient{},
+			randomString(), randomString(), randomString(), libsveltosv1alpha1.FeatureClusterHealthCheck,
+			libsveltosv1alpha1.ClusterTypeSveltos, deployer.Options{}, logger)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("deliberate panic"))
+		Expect(controllers.GetReconcilePanics("evaluation")).To(Equal(panics + 1))
+	})
+
 	It("processClusterHealthCheck queues job", func() {
 		clusterNamespace := randomString()
 		clusterName := randomString()
@@ -373,21 +533,11 @@ var _ = Describe("ClusterHealthCheck deployer", func() {
 	})
 
 	It("deployHealthChecks deploys healthChecks", func() {
-		healthCheck := &libsveltosv1alpha1.HealthCheck{
-			ObjectMeta: metav1.ObjectMeta{
-				Name: randomString(),
-			},
-			Spec: libsveltosv1alpha1.HealthCheckSpec{
-				ResourceSelectors: []libsveltosv1alpha1.ResourceSelector{
-					{
-						Kind:    randomString(),
-						Group:   randomString(),
-						Version: randomString(),
-					},
-				},
-				EvaluateHealth: randomString(),
-			},
-		}
+		healthCheck := testhelpers.NewHealthCheck().
+			WithName(randomString()).
+			WithResourceSelector(randomString(), randomString(), randomString()).
+			WithEvaluateHealth(randomString()).
+			Build()
 
 		Expect(testEnv.Create(context.TODO(), healthCheck)).To(Succeed())
 		Expect(waitForObject(context.TODO(), testEnv.Client, healthCheck)).To(Succeed())
@@ -404,47 +554,22 @@ var _ = Describe("ClusterHealthCheck deployer", func() {
 		Expect(testEnv.Create(context.TODO(), ns)).To(Succeed())
 		Expect(waitForObject(context.TODO(), testEnv.Client, ns)).To(Succeed())
 
-		cluster := &clusterv1.Cluster{
-			ObjectMeta: metav1.ObjectMeta{
-				Namespace: clusterNamespace,
-				Name:      clusterName,
-			},
-		}
+		cluster := testhelpers.NewCluster().WithNamespace(clusterNamespace).WithName(clusterName).Build()
 		Expect(testEnv.Create(context.TODO(), cluster)).To(Succeed())
 		Expect(waitForObject(context.TODO(), testEnv.Client, cluster)).To(Succeed())
 
-		chc := &libsveltosv1alpha1.ClusterHealthCheck{
-			ObjectMeta: metav1.ObjectMeta{
+		chc := testhelpers.NewClusterHealthCheck().
+			WithName(randomString()).
+			WithHealthCheckLivenessCheck(randomString(), healthCheck.Name).
+			WithNotification(libsveltosv1alpha1.Notification{
 				Name: randomString(),
-			},
-			Spec: libsveltosv1alpha1.ClusterHealthCheckSpec{
-				LivenessChecks: []libsveltosv1alpha1.LivenessCheck{
-					{
-						Name: randomString(),
-						Type: libsveltosv1alpha1.LivenessTypeHealthCheck,
-						LivenessSourceRef: &corev1.ObjectReference{
-							APIVersion: libsveltosv1alpha1.GroupVersion.String(),
-							Kind:       libsveltosv1alpha1.HealthCheckKind,
-							Name:       healthCheck.Name,
-						},
-					},
-				},
-				Notifications: []libsveltosv1alpha1.Notification{
-					{
-						Name: randomString(),
-						Type: libsveltosv1alpha1.NotificationTypeKubernetesEvent,
-					},
-				},
-			},
-			Status: libsveltosv1alpha1.ClusterHealthCheckStatus{
-				MatchingClusterRefs: []corev1.ObjectReference{
-					{
-						Kind: ClusterKind, APIVersion: clusterv1.GroupVersion.String(), Namespace: clusterNamespace, Name: clusterName,
-					},
-				},
-				ClusterConditions: []libsveltosv1alpha1.ClusterCondition{},
-			},
-		}
+				Type: libsveltosv1alpha1.NotificationTypeKubernetesEvent,
+			}).
+			WithMatchingClusterRefs(corev1.ObjectReference{
+				Kind: ClusterKind, APIVersion: clusterv1.GroupVersion.String(), Namespace: clusterNamespace, Name: clusterName,
+			}).
+			WithClusterConditions().
+			Build()
 
 		Expect(testEnv.Create(context.TODO(), chc)).To(Succeed())
 		Expect(waitForObject(context.TODO(), testEnv.Client, chc)).To(Succeed())
@@ -481,21 +606,11 @@ var _ = Describe("ClusterHealthCheck deployer", func() {
 	})
 
 	It("removeStaleHealthChecks removes healthCheck deployed by a ClusterHealthCheck and not referenced anymore", func() {
-		healthCheck := &libsveltosv1alpha1.HealthCheck{
-			ObjectMeta: metav1.ObjectMeta{
-				Name: randomString(),
-			},
-			Spec: libsveltosv1alpha1.HealthCheckSpec{
-				ResourceSelectors: []libsveltosv1alpha1.ResourceSelector{
-					{
-						Kind:    randomString(),
-						Group:   randomString(),
-						Version: randomString(),
-					},
-				},
-				EvaluateHealth: randomString(),
-			},
-		}
+		healthCheck := testhelpers.NewHealthCheck().
+			WithName(randomString()).
+			WithResourceSelector(randomString(), randomString(), randomString()).
+			WithEvaluateHealth(randomString()).
+			Build()
 
 		Expect(testEnv.Create(context.TODO(), healthCheck)).To(Succeed())
 		Expect(waitForObject(context.TODO(), testEnv.Client, healthCheck)).To(Succeed())
@@ -512,47 +627,30 @@ var _ = Describe("ClusterHealthCheck deployer", func() {
 		Expect(testEnv.Create(context.TODO(), ns)).To(Succeed())
 		Expect(waitForObject(context.TODO(), testEnv.Client, ns)).To(Succeed())
 
-		cluster := &clusterv1.Cluster{
-			ObjectMeta: metav1.ObjectMeta{
-				Namespace: clusterNamespace,
-				Name:      clusterName,
-			},
-		}
+		cluster := testhelpers.NewCluster().WithNamespace(clusterNamespace).WithName(clusterName).Build()
 		Expect(testEnv.Create(context.TODO(), cluster)).To(Succeed())
 		Expect(waitForObject(context.TODO(), testEnv.Client, cluster)).To(Succeed())
 
-		chc := &libsveltosv1alpha1.ClusterHealthCheck{
-			ObjectMeta: metav1.ObjectMeta{
+		chc := testhelpers.NewClusterHealthCheck().
+			WithName(randomString()).
+			WithLivenessCheck(libsveltosv1alpha1.LivenessCheck{
 				Name: randomString(),
-			},
-			Spec: libsveltosv1alpha1.ClusterHealthCheckSpec{
-				LivenessChecks: []libsveltosv1alpha1.LivenessCheck{
-					{
-						Name: randomString(),
-						Type: libsveltosv1alpha1.LivenessTypeHealthCheck,
-						LivenessSourceRef: &corev1.ObjectReference{
-							APIVersion: libsveltosv1alpha1.GroupVersion.Group,
-							Kind:       libsveltosv1alpha1.HealthCheckKind,
-							Name:       randomString(), // make it reference different healthCheck
-						},
-					},
-				},
-				Notifications: []libsveltosv1alpha1.Notification{
-					{
-						Name: randomString(),
-						Type: libsveltosv1alpha1.NotificationTypeKubernetesEvent,
-					},
-				},
-			},
-			Status: libsveltosv1alpha1.ClusterHealthCheckStatus{
-				MatchingClusterRefs: []corev1.ObjectReference{
-					{
-						Kind: ClusterKind, APIVersion: clusterv1.GroupVersion.String(), Namespace: clusterNamespace, Name: clusterName,
-					},
+				Type: libsveltosv1alpha1.LivenessTypeHealthCheck,
+				LivenessSourceRef: &corev1.ObjectReference{
+					APIVersion: libsveltosv1alpha1.GroupVersion.Group,
+					Kind:       libsveltosv1alpha1.HealthCheckKind,
+					Name:       randomString(), // make it reference different healthCheck
 				},
-				ClusterConditions: []libsveltosv1alpha1.ClusterCondition{},
-			},
-		}
+			}).
+			WithNotification(libsveltosv1alpha1.Notification{
+				Name: randomString(),
+				Type: libsveltosv1alpha1.NotificationTypeKubernetesEvent,
+			}).
+			WithMatchingClusterRefs(corev1.ObjectReference{
+				Kind: ClusterKind, APIVersion: clusterv1.GroupVersion.String(), Namespace: clusterNamespace, Name: clusterName,
+			}).
+			WithClusterConditions().
+			Build()
 
 		Expect(testEnv.Create(context.TODO(), chc)).To(Succeed())
 		Expect(waitForObject(context.TODO(), testEnv.Client, chc)).To(Succeed())
@@ -589,33 +687,27 @@ var _ = Describe("ClusterHealthCheck deployer", func() {
 		healthCheckName1 := randomString()
 		healthCheckName2 := randomString()
 
-		chc := &libsveltosv1alpha1.ClusterHealthCheck{
-			ObjectMeta: metav1.ObjectMeta{
+		chc := testhelpers.NewClusterHealthCheck().
+			WithName(randomString()).
+			WithLivenessCheck(libsveltosv1alpha1.LivenessCheck{
 				Name: randomString(),
-			},
-			Spec: libsveltosv1alpha1.ClusterHealthCheckSpec{
-				LivenessChecks: []libsveltosv1alpha1.LivenessCheck{
-					{
-						Name: randomString(),
-						Type: libsveltosv1alpha1.LivenessTypeHealthCheck,
-						LivenessSourceRef: &corev1.ObjectReference{
-							APIVersion: libsveltosv1alpha1.GroupVersion.Group,
-							Kind:       libsveltosv1alpha1.HealthCheckKind,
-							Name:       healthCheckName1,
-						},
-					},
-					{
-						Name: randomString(),
-						Type: libsveltosv1alpha1.LivenessTypeHealthCheck,
-						LivenessSourceRef: &corev1.ObjectReference{
-							APIVersion: libsveltosv1alpha1.GroupVersion.Group,
-							Kind:       libsveltosv1alpha1.HealthCheckKind,
-							Name:       healthCheckName2,
-						},
-					},
+				Type: libsveltosv1alpha1.LivenessTypeHealthCheck,
+				LivenessSourceRef: &corev1.ObjectReference{
+					APIVersion: libsveltosv1alpha1.GroupVersion.Group,
+					Kind:       libsveltosv1alpha1.HealthCheckKind,
+					Name:       healthCheckName1,
 				},
-			},
-		}
+			}).
+			WithLivenessCheck(libsveltosv1alpha1.LivenessCheck{
+				Name: randomString(),
+				Type: libsveltosv1alpha1.LivenessTypeHealthCheck,
+				LivenessSourceRef: &corev1.ObjectReference{
+					APIVersion: libsveltosv1alpha1.GroupVersion.Group,
+					Kind:       libsveltosv1alpha1.HealthCheckKind,
+					Name:       healthCheckName2,
+				},
+			}).
+			Build()
 
 		referenced := controllers.GetReferencedHealthChecks(chc, logger)
 
@@ -0,0 +1,279 @@
+/*
+Copyright 2024. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers_test
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2/textlogger"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/projectsveltos/healthcheck-manager/controllers"
+	"github.com/projectsveltos/healthcheck-manager/controllers/testhelpers"
+	libsveltosv1alpha1 "github.com/projectsveltos/libsveltos/api/v1alpha1"
+	"github.com/projectsveltos/libsveltos/lib/deployer"
+	fakedeployer "github.com/projectsveltos/libsveltos/lib/deployer/fake"
+	libsveltosset "github.com/projectsveltos/libsveltos/lib/set"
+)
+
+// panickingPublisher panics on any Publish
+type panickingPublisher struct{}
+
+func (p *panickingPublisher) Publish(ctx context.Context, event *controllers.HealthEvaluationEvent) error {
+	panic("publisher panic")
+}
+
+var _ = Describe("ClusterHealthCheck: end to end", func() {
+	It("ClusterHealthCheck reports a degraded HealthCheck for a matching SveltosCluster", func() {
+		logger := textlogger.NewLogger(textlogger.NewConfig(textlogger.Verbosity(1)))
+
+		healthCheck := testhelpers.NewHealthCheck().
+			WithName(randomString()).
+			WithResourceSelector("apps", "v1", "Deployment").
+			WithEvaluateHealth(randomString()).
+			Build()
+		Expect(testEnv.Create(context.TODO(), healthCheck)).To(Succeed())
+		Expect(waitForObject(context.TODO(), testEnv.Client, healthCheck)).To(Succeed())
+
+		clusterNamespace := randomString()
+		clusterName := randomString()
+		clusterType := libsveltosv1alpha1.ClusterTypeSveltos
+
+		ns := &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: clusterNamespace,
+			},
+		}
+		Expect(testEnv.Create(context.TODO(), ns)).To(Succeed())
+		Expect(waitForObject(context.TODO(), testEnv.Client, ns)).To(Succeed())
+
+		key := randomString()
+		value := randomString()
+		sveltosCluster := testhelpers.NewSveltosCluster().
+			WithNamespace(clusterNamespace).
+			WithName(clusterName).
+			WithLabels(map[string]string{key: value}).
+			WithKubeconfigName(clusterName + sveltosKubeconfigPostfix).
+			Build()
+		Expect(testEnv.Create(context.TODO(), sveltosCluster)).To(Succeed())
+		Expect(waitForObject(context.TODO(), testEnv.Client, sveltosCluster)).To(Succeed())
+
+		sveltosCluster.Status.Ready = true
+		Expect(testEnv.Status().Update(context.TODO(), sveltosCluster)).To(Succeed())
+
+		// testEnv is used as both management cluster and managed cluster
+		createSecretWithKubeconfig(clusterNamespace, clusterName)
+
+		livenessCheckName := randomString()
+		chc := testhelpers.NewClusterHealthCheck().
+			WithName(randomString()).
+			WithClusterSelector(key+"="+value).
+			WithHealthCheckLivenessCheck(livenessCheckName, healthCheck.Name).
+			Build()
+		Expect(testEnv.Create(context.TODO(), chc)).To(Succeed())
+		Expect(waitForObject(context.TODO(), testEnv.Client, chc)).To(Succeed())
+
+		degradedMessage := randomString()
+		hcr := &libsveltosv1alpha1.HealthCheckReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: clusterNamespace,
+				Name:      randomString(),
+				Labels:    libsveltosv1alpha1.GetHealthCheckReportLabels(healthCheck.Name, clusterName, &clusterType),
+			},
+			Spec: libsveltosv1alpha1.HealthCheckReportSpec{
+				ClusterNamespace: clusterNamespace,
+				ClusterName:      clusterName,
+				ClusterType:      clusterType,
+				HealthCheckName:  healthCheck.Name,
+				ResourceStatuses: []libsveltosv1alpha1.ResourceStatus{
+					{
+						ObjectRef:    corev1.ObjectReference{Kind: "Deployment", Namespace: randomString(), Name: randomString()},
+						HealthStatus: libsveltosv1alpha1.HealthStatusDegraded,
+						Message:      degradedMessage,
+					},
+				},
+			},
+		}
+		Expect(testEnv.Create(context.TODO(), hcr)).To(Succeed())
+		Expect(waitForObject(context.TODO(), testEnv.Client, hcr)).To(Succeed())
+
+		dep := fakedeployer.GetClient(context.TODO(), logger, testEnv.Client)
+		controllers.RegisterFeatures(dep, logger)
+
+		reconciler := controllers.ClusterHealthCheckReconciler{
+			Client:              testEnv.Client,
+			Deployer:            dep,
+			Scheme:              testEnv.Scheme(),
+			Mux:                 sync.Mutex{},
+			ClusterMap:          make(map[corev1.ObjectReference]*libsveltosset.Set),
+			CHCToClusterMap:     make(map[types.NamespacedName]*libsveltosset.Set),
+			ClusterHealthChecks: make(map[corev1.ObjectReference]libsveltosv1alpha1.Selector),
+			HealthCheckMap:      make(map[corev1.ObjectReference]*libsveltosset.Set),
+			CHCToHealthCheckMap: make(map[types.NamespacedName]*libsveltosset.Set),
+		}
+
+		chcName := types.NamespacedName{Name: chc.Name}
+		_, err := reconciler.Reconcile(context.TODO(), ctrl.Request{NamespacedName: chcName})
+		Expect(err).ToNot(HaveOccurred())
+
+		// Reconcile finds matching SveltosCluster and adds an entry in Status.ClusterConditions for it.
+		Eventually(func() bool {
+			currentChc := &libsveltosv1alpha1.ClusterHealthCheck{}
+			err := testEnv.Get(context.TODO(), chcName, currentChc)
+			if err != nil {
+				return false
+			}
+			if len(currentChc.Status.MatchingClusterRefs) != 1 {
+				return false
+			}
+			return len(currentChc.Status.ClusterConditions) == 1
+		}, timeout, pollingInterval).Should(BeTrue())
+
+		// Fake deployer does not process requests. Run the worker function directly.
+		Expect(controllers.ProcessClusterHealthCheckForCluster(context.TODO(), testEnv.Client,
+			clusterNamespace, clusterName, chc.Name, libsveltosv1alpha1.FeatureClusterHealthCheck,
+			clusterType, deployer.Options{}, logger)).To(Succeed())
+
+		// HealthCheckReport reports a degraded resource, so liveness check must be reported as failing
+		Eventually(func() bool {
+			currentChc := &libsveltosv1alpha1.ClusterHealthCheck{}
+			err := testEnv.Get(context.TODO(), chcName, currentChc)
+			if err != nil {
+				return false
+			}
+			for i := range currentChc.Status.ClusterConditions {
+				cc := &currentChc.Status.ClusterConditions[i]
+				if !controllers.IsClusterConditionForCluster(cc, clusterNamespace, clusterName, clusterType) {
+					continue
+				}
+				for j := range cc.Conditions {
+					condition := &cc.Conditions[j]
+					if condition.Name == livenessCheckName &&
+						condition.Status == corev1.ConditionFalse &&
+						strings.Contains(condition.Message, degradedMessage) {
+
+						return true
+					}
+				}
+			}
+			return false
+		}, timeout, pollingInterval).Should(BeTrue())
+	})
+
+	It("ClusterHealthCheck worker recovers from panics while evaluating health checks", func() {
+		logger := textlogger.NewLogger(textlogger.NewConfig(textlogger.Verbosity(1)))
+
+		recorder := record.NewFakeRecorder(1)
+		controllers.SetManagementRecorder(recorder)
+		defer controllers.SetManagementRecorder(nil)
+
+		controllers.SetEvaluationPublisher(&panickingPublisher{})
+		defer controllers.SetEvaluationPublisher(nil)
+
+		clusterNamespace := randomString()
+		clusterName := randomString()
+		clusterType := libsveltosv1alpha1.ClusterTypeSveltos
+
+		ns := &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: clusterNamespace,
+			},
+		}
+		Expect(testEnv.Create(context.TODO(), ns)).To(Succeed())
+		Expect(waitForObject(context.TODO(), testEnv.Client, ns)).To(Succeed())
+
+		key := randomString()
+		value := randomString()
+		sveltosCluster := testhelpers.NewSveltosCluster().
+			WithNamespace(clusterNamespace).
+			WithName(clusterName).
+			WithLabels(map[string]string{key: value}).
+			WithKubeconfigName(clusterName + sveltosKubeconfigPostfix).
+			Build()
+		Expect(testEnv.Create(context.TODO(), sveltosCluster)).To(Succeed())
+		Expect(waitForObject(context.TODO(), testEnv.Client, sveltosCluster)).To(Succeed())
+
+		sveltosCluster.Status.Ready = true
+		Expect(testEnv.Status().Update(context.TODO(), sveltosCluster)).To(Succeed())
+
+		// testEnv is used as both management cluster and managed cluster
+		createSecretWithKubeconfig(clusterNamespace, clusterName)
+
+		chc := testhelpers.NewClusterHealthCheck().
+			WithName(randomString()).
+			WithClusterSelector(key + "=" + value).
+			WithLivenessCheck(libsveltosv1alpha1.LivenessCheck{
+				Name: randomString(),
+				Type: libsveltosv1alpha1.LivenessTypeAddons,
+			}).
+			Build()
+		Expect(testEnv.Create(context.TODO(), chc)).To(Succeed())
+		Expect(waitForObject(context.TODO(), testEnv.Client, chc)).To(Succeed())
+
+		dep := fakedeployer.GetClient(context.TODO(), logger, testEnv.Client)
+		controllers.RegisterFeatures(dep, logger)
+
+		reconciler := controllers.ClusterHealthCheckReconciler{
+			Client:              testEnv.Client,
+			Deployer:            dep,
+			Scheme:              testEnv.Scheme(),
+			Mux:                 sync.Mutex{},
+			ClusterMap:          make(map[corev1.ObjectReference]*libsveltosset.Set),
+			CHCToClusterMap:     make(map[types.NamespacedName]*libsveltosset.Set),
+			ClusterHealthChecks: make(map[corev1.ObjectReference]libsveltosv1alpha1.Selector),
+			HealthCheckMap:      make(map[corev1.ObjectReference]*libsveltosset.Set),
+			CHCToHealthCheckMap: make(map[types.NamespacedName]*libsveltosset.Set),
+		}
+
+		chcName := types.NamespacedName{Name: chc.Name}
+		_, err := reconciler.Reconcile(context.TODO(), ctrl.Request{NamespacedName: chcName})
+		Expect(err).ToNot(HaveOccurred())
+
+		Eventually(func() bool {
+			currentChc := &libsveltosv1alpha1.ClusterHealthCheck{}
+			err := testEnv.Get(context.TODO(), chcName, currentChc)
+			if err != nil {
+				return false
+			}
+			return len(currentChc.Status.ClusterConditions) == 1
+		}, timeout, pollingInterval).Should(BeTrue())
+
+		panics := controllers.GetReconcilePanics("evaluation")
+
+		// Publisher panics once liveness checks are evaluated. Worker must return an error instead of crashing.
+		err = controllers.ProcessClusterHealthCheckForCluster(context.TODO(), testEnv.Client,
+			clusterNamespace, clusterName, chc.Name, libsveltosv1alpha1.FeatureClusterHealthCheck,
+			clusterType, deployer.Options{}, logger)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("publisher panic"))
+		Expect(controllers.GetReconcilePanics("evaluation")).To(Equal(panics + 1))
+
+		Expect(recorder.Events).To(HaveLen(1))
+		recorded := <-recorder.Events
+		Expect(recorded).To(ContainSubstring(controllers.ReconcilePanickedReason))
+		Expect(recorded).To(ContainSubstring("publisher panic"))
+	})
+})
@@ -17,7 +17,9 @@ limitations under the License.
 package controllers
 
 import (
+	"fmt"
 	"reflect"
+	"strings"
 
 	"github.com/go-logr/logr"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
@@ -29,8 +31,19 @@ import (
 	logs "github.com/projectsveltos/libsveltos/lib/logsettings"
 )
 
+const (
+	// SuppressedHealthCheckAnnotation, when set to "true" on a HealthCheck, prevents
+	// any update of that HealthCheck from triggering a reconciliation
+	SuppressedHealthCheckAnnotation = "healthcheck.sveltos.io/suppressed"
+)
+
 type ClusterPredicate struct {
 	Logger logr.Logger
+
+	// RespawnPausedClusters, when set, makes Create reconcile paused clusters as well.
+	// Reconciler takes care of skipping paused clusters. This guarantees a cluster created
+	// paused is processed once unpaused, even if no update event is received.
+	RespawnPausedClusters bool
 }
 
 func (p ClusterPredicate) Create(obj event.TypedCreateEvent[*clusterv1.Cluster]) bool {
@@ -47,6 +60,14 @@ func (p ClusterPredicate) Create(obj event.TypedCreateEvent[*clusterv1.Cluster])
 		)
 		return true
 	}
+
+	if p.RespawnPausedClusters {
+		log.V(logs.LogVerbose).Info(
+			"Cluster is paused but respawnPausedClusters is set.  Will attempt to reconcile associated ClusterHealthChecks.",
+		)
+		return true
+	}
+
 	log.V(logs.LogVerbose).Info(
 		"Cluster did not match expected conditions.  Will not attempt to reconcile associated ClusterHealthChecks.")
 	return false
@@ -79,6 +100,53 @@ func (p ClusterPredicate) Update(obj event.TypedUpdateEvent[*clusterv1.Cluster])
 		return true
 	}
 
+	// return true if cluster opted in or out of health checking
+	if _, changed := watchedAnnotationChanged(oldCluster.Annotations, newCluster.Annotations,
+		[]string{SkipClusterAnnotation}); changed {
+
+		log.V(logs.LogVerbose).Info(
+			"Cluster skip annotation changed. Will attempt to reconcile associated ClusterHealthChecks.",
+		)
+		return true
+	}
+
+	// return true if Cluster.Status.ControlPlaneReady has changed
+	if oldCluster.Status.ControlPlaneReady != newCluster.Status.ControlPlaneReady {
+		log.V(logs.LogVerbose).Info(
+			"Cluster control plane readiness changed. Will attempt to reconcile associated ClusterHealthChecks.",
+		)
+		return true
+	}
+
+	// return true if Cluster.Spec.ControlPlaneRef has changed. During a control plane migration
+	// health results computed against previous control plane are not accurate anymore.
+	if !reflect.DeepEqual(oldCluster.Spec.ControlPlaneRef, newCluster.Spec.ControlPlaneRef) {
+		log.V(logs.LogVerbose).Info(
+			"Cluster control plane reference changed. Will attempt to reconcile associated ClusterHealthChecks.",
+		)
+		return true
+	}
+
+	// return true if Cluster.Spec.Topology has changed (version, class, variables...). A topology
+	// upgrade changes which resources exist and how those should be evaluated.
+	if !reflect.DeepEqual(oldCluster.Spec.Topology, newCluster.Spec.Topology) {
+		log.V(logs.LogVerbose).Info(
+			"Cluster topology changed. Will attempt to reconcile associated ClusterHealthChecks.",
+		)
+		return true
+	}
+
+	// return true if Cluster.Status.ObservedGeneration has caught up with Generation, i.e. latest spec
+	// has been processed by the cluster controller
+	if oldCluster.Status.ObservedGeneration < newCluster.Generation &&
+		newCluster.Status.ObservedGeneration >= newCluster.Generation {
+
+		log.V(logs.LogVerbose).Info(
+			"Cluster observed generation caught up. Will attempt to reconcile associated ClusterHealthChecks.",
+		)
+		return true
+	}
+
 	// otherwise, return false
 	log.V(logs.LogVerbose).Info(
 		"Cluster did not match expected conditions.  Will not attempt to reconcile associated ClusterHealthChecks.")
@@ -89,6 +157,7 @@ func (p ClusterPredicate) Delete(obj event.TypedDeleteEvent[*clusterv1.Cluster])
 	log := p.Logger.WithValues("predicate", "deleteEvent",
 		"namespace", obj.Object.GetNamespace(),
 		"cluster", obj.Object.GetName(),
+		"uid", obj.Object.GetUID(),
 	)
 	log.V(logs.LogVerbose).Info(
 		"Cluster deleted.  Will attempt to reconcile associated ClusterHealthChecks.")
@@ -105,8 +174,81 @@ func (p ClusterPredicate) Generic(obj event.TypedGenericEvent[*clusterv1.Cluster
 	return false
 }
 
+// ClusterConditionFilteredPredicates predicates for ClusterAPI Cluster. Only changes (Status or Reason,
+// including condition being added or removed) to one of the conditions in relevantConditions
+// trigger a reconciliation. Changes to any other condition are ignored.
+func ClusterConditionFilteredPredicates(relevantConditions []clusterv1.ConditionType, logger logr.Logger) predicate.Funcs {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			newCluster, ok := e.ObjectNew.(*clusterv1.Cluster)
+			if !ok {
+				return false
+			}
+			log := logger.WithValues("predicate", "updateEvent",
+				"namespace", newCluster.Namespace,
+				"cluster", newCluster.Name,
+			)
+
+			oldCluster, ok := e.ObjectOld.(*clusterv1.Cluster)
+			if !ok || oldCluster == nil {
+				log.V(logs.LogVerbose).Info("Old Cluster is nil. Reconcile ClusterHealthCheck")
+				return true
+			}
+
+			for i := range relevantConditions {
+				oldCondition := getClusterCondition(oldCluster, relevantConditions[i])
+				newCondition := getClusterCondition(newCluster, relevantConditions[i])
+				if hasClusterConditionChanged(oldCondition, newCondition) {
+					log.V(logs.LogVerbose).Info(fmt.Sprintf(
+						"Cluster condition %s changed. Will attempt to reconcile associated ClusterHealthChecks.",
+						relevantConditions[i]))
+					return true
+				}
+			}
+
+			// otherwise, return false
+			log.V(logs.LogVerbose).Info(
+				"Cluster did not match expected conditions.  Will not attempt to reconcile associated ClusterHealthChecks.")
+			return false
+		},
+		CreateFunc: func(e event.CreateEvent) bool {
+			return true
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return true
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return false
+		},
+	}
+}
+
+// getClusterCondition returns Cluster condition of type conditionType. Nil if not present.
+func getClusterCondition(cluster *clusterv1.Cluster, conditionType clusterv1.ConditionType) *clusterv1.Condition {
+	for i := range cluster.Status.Conditions {
+		if cluster.Status.Conditions[i].Type == conditionType {
+			return &cluster.Status.Conditions[i]
+		}
+	}
+	return nil
+}
+
+// hasClusterConditionChanged returns true if condition was added, removed or its Status or Reason changed
+func hasClusterConditionChanged(oldCondition, newCondition *clusterv1.Condition) bool {
+	if oldCondition == nil || newCondition == nil {
+		return oldCondition != newCondition
+	}
+
+	return oldCondition.Status != newCondition.Status ||
+		oldCondition.Reason != newCondition.Reason
+}
+
 type MachinePredicate struct {
 	Logger logr.Logger
+
+	// WatchAnnotationKeys is the set of Machine annotation keys whose changes
+	// (key added, removed or value modified) trigger a reconciliation
+	WatchAnnotationKeys []string
 }
 
 func (p MachinePredicate) Create(obj event.TypedCreateEvent[*clusterv1.Machine]) bool {
@@ -150,6 +292,15 @@ func (p MachinePredicate) Update(obj event.TypedUpdateEvent[*clusterv1.Machine])
 		return true
 	}
 
+	// return true if any watched annotation has changed
+	if key, changed := watchedAnnotationChanged(oldMachine.Annotations, newMachine.Annotations,
+		p.WatchAnnotationKeys); changed {
+
+		log.V(logs.LogVerbose).Info(fmt.Sprintf(
+			"Machine annotation %s changed. Will attempt to reconcile associated ClusterHealthChecks.", key))
+		return true
+	}
+
 	// otherwise, return false
 	log.V(logs.LogVerbose).Info(
 		"Machine did not match expected conditions.  Will not attempt to reconcile associated ClusterHealthChecks.")
@@ -176,6 +327,20 @@ func (p MachinePredicate) Generic(obj event.TypedGenericEvent[*clusterv1.Machine
 	return false
 }
 
+// watchedAnnotationChanged returns true, along with the annotation key, if any of the
+// watched annotation keys was added, removed or had its value changed.
+func watchedAnnotationChanged(oldAnnotations, newAnnotations map[string]string, watchKeys []string) (string, bool) {
+	for _, key := range watchKeys {
+		oldValue, oldOk := oldAnnotations[key]
+		newValue, newOk := newAnnotations[key]
+		if oldOk != newOk || oldValue != newValue {
+			return key, true
+		}
+	}
+
+	return "", false
+}
+
 // SveltosClusterPredicates predicates for sveltos Cluster. ClusterHealthCheckReconciler watches sveltos Cluster events
 // and react to those by reconciling itself based on following predicates
 func SveltosClusterPredicates(logger logr.Logger) predicate.Funcs {
@@ -213,6 +378,32 @@ func SveltosClusterPredicates(logger logr.Logger) predicate.Funcs {
 				return true
 			}
 
+			// return true if cluster opted in or out of health checking
+			if _, changed := watchedAnnotationChanged(oldCluster.Annotations, newCluster.Annotations,
+				[]string{SkipClusterAnnotation}); changed {
+
+				log.V(logs.LogVerbose).Info(
+					"Cluster skip annotation changed. Will attempt to reconcile associated ClusterHealthChecks.",
+				)
+				return true
+			}
+
+			// Status (and metadata) updates, like token renewal timestamps, do not bump generation.
+			// Status.Ready, labels and skip annotation, which change without a generation bump, are already evaluated above.
+			// Any further check only looks at Spec.
+			if newCluster.GetGeneration() == oldCluster.GetGeneration() {
+				log.V(logs.LogVerbose).Info(
+					"Cluster generation did not change. Will not attempt to reconcile associated ClusterHealthChecks.")
+				return false
+			}
+
+			// kubeconfig used to evaluate health might be renewed differently. Cached kubeconfig might be stale
+			if !reflect.DeepEqual(oldCluster.Spec.TokenRequestRenewalOption, newCluster.Spec.TokenRequestRenewalOption) {
+				log.V(logs.LogVerbose).Info(
+					"Cluster TokenRequestRenewalOption changed. Will attempt to reconcile associated ClusterHealthChecks.")
+				return true
+			}
+
 			// otherwise, return false
 			log.V(logs.LogVerbose).Info(
 				"Cluster did not match expected conditions.  Will not attempt to reconcile associated ClusterHealthChecks.")
@@ -232,6 +423,16 @@ func SveltosClusterPredicates(logger logr.Logger) predicate.Funcs {
 				)
 				return true
 			}
+
+			// A cluster created already ready needs immediate evaluation, regardless of pause state.
+			// Reconciler takes care of skipping paused clusters.
+			if cluster.Status.Ready {
+				log.V(logs.LogVerbose).Info(
+					"Cluster is ready.  Will attempt to reconcile associated ClusterHealthChecks.",
+				)
+				return true
+			}
+
 			log.V(logs.LogVerbose).Info(
 				"Cluster did not match expected conditions.  Will not attempt to reconcile associated ClusterHealthChecks.")
 			return false
@@ -240,6 +441,7 @@ func SveltosClusterPredicates(logger logr.Logger) predicate.Funcs {
 			log := logger.WithValues("predicate", "deleteEvent",
 				"namespace", e.Object.GetNamespace(),
 				"cluster", e.Object.GetName(),
+				"uid", e.Object.GetUID(),
 			)
 			log.V(logs.LogVerbose).Info(
 				"Cluster deleted.  Will attempt to reconcile associated ClusterHealthChecks.")
@@ -258,8 +460,9 @@ func SveltosClusterPredicates(logger logr.Logger) predicate.Funcs {
 }
 
 // ClusterSummaryPredicates predicates for clustersummary. ClusterHealthCheckReconciler watches sveltos ClusterSummary
-// events and react to those by reconciling itself based on following predicates
-func ClusterSummaryPredicates(logger logr.Logger) predicate.Funcs {
+// events and react to those by reconciling itself based on following predicates.
+// If managedNamespaces is set, events for ClusterSummaries in any other namespace are ignored.
+func ClusterSummaryPredicates(logger logr.Logger, managedNamespaces ...string) predicate.Funcs {
 	return predicate.Funcs{
 		UpdateFunc: func(e event.UpdateEvent) bool {
 			newClusterSummary := e.ObjectNew.(*configv1alpha1.ClusterSummary)
@@ -269,6 +472,12 @@ func ClusterSummaryPredicates(logger logr.Logger) predicate.Funcs {
 				"clustersummary", newClusterSummary.Name,
 			)
 
+			if !isManagedNamespace(newClusterSummary.Namespace, managedNamespaces) {
+				log.V(logs.LogVerbose).Info(
+					"ClusterSummary is not in a managed namespace. Will not attempt to reconcile associated ClusterHealthChecks.")
+				return false
+			}
+
 			if oldClusterSummary == nil {
 				log.V(logs.LogVerbose).Info("Old ClusterSummary is nil. Reconcile ClusterHealthCheck")
 				return true
@@ -281,6 +490,13 @@ func ClusterSummaryPredicates(logger logr.Logger) predicate.Funcs {
 				return true
 			}
 
+			// return true if GVKs deployed by ClusterSummary have changed (for instance a new CRD was deployed)
+			if !reflect.DeepEqual(oldClusterSummary.Status.DeployedGVKs, newClusterSummary.Status.DeployedGVKs) {
+				log.V(logs.LogVerbose).Info(
+					"ClusterSummary Status.DeployedGVKs changed. Will attempt to reconcile associated ClusterHealthChecks.")
+				return true
+			}
+
 			// otherwise, return false
 			log.V(logs.LogVerbose).Info(
 				"ClusterSummary did not match expected conditions.  Will not attempt to reconcile associated ClusterHealthChecks.")
@@ -301,6 +517,13 @@ func ClusterSummaryPredicates(logger logr.Logger) predicate.Funcs {
 				"namespace", e.Object.GetNamespace(),
 				"clustersummary", e.Object.GetName(),
 			)
+
+			if !isManagedNamespace(e.Object.GetNamespace(), managedNamespaces) {
+				log.V(logs.LogVerbose).Info(
+					"ClusterSummary is not in a managed namespace. Will not attempt to reconcile associated ClusterHealthChecks.")
+				return false
+			}
+
 			log.V(logs.LogVerbose).Info(
 				"ClusterSummary deleted.  Will attempt to reconcile associated ClusterHealthChecks.")
 			return true
@@ -317,6 +540,22 @@ func ClusterSummaryPredicates(logger logr.Logger) predicate.Funcs {
 	}
 }
 
+// isManagedNamespace returns true if namespace is in managedNamespaces.
+// An empty managedNamespaces means all namespaces are managed.
+func isManagedNamespace(namespace string, managedNamespaces []string) bool {
+	if len(managedNamespaces) == 0 {
+		return true
+	}
+
+	for i := range managedNamespaces {
+		if managedNamespaces[i] == namespace {
+			return true
+		}
+	}
+
+	return false
+}
+
 // HealthCheckReportPredicates predicates for HealthCheckReport. ClusterHealthCheckReconciler watches sveltos
 // HealthCheckReport events and react to those by reconciling itself based on following predicates
 func HealthCheckReportPredicates(logger logr.Logger) predicate.Funcs {
@@ -334,6 +573,13 @@ func HealthCheckReportPredicates(logger logr.Logger) predicate.Funcs {
 				return true
 			}
 
+			// return true if resources were added or removed. Cheaper than comparing the whole Spec
+			if len(oldHCR.Spec.ResourceStatuses) != len(newHCR.Spec.ResourceStatuses) {
+				log.V(logs.LogVerbose).Info(
+					"HealthCheckReport number of resources changed. Will attempt to reconcile associated ClusterHealthChecks.")
+				return true
+			}
+
 			// return true if HealthCheckReport Spec has changed
 			if !reflect.DeepEqual(oldHCR.Spec, newHCR.Spec) {
 				log.V(logs.LogVerbose).Info(
@@ -388,18 +634,43 @@ func HealthCheckPredicates(logger logr.Logger) predicate.Funcs {
 				"healthCheck", newHC.Name,
 			)
 
+			if newHC.GetAnnotations()[SuppressedHealthCheckAnnotation] == "true" {
+				log.V(logs.LogVerbose).Info(
+					"HealthCheck is suppressed.  Will not attempt to reconcile associated ClusterHealthChecks.")
+				return false
+			}
+
 			if oldHC == nil {
 				log.V(logs.LogVerbose).Info("Old HealthCheck is nil. Reconcile ClusterHealthCheck")
 				return true
 			}
 
+			// return true if HealthCheck is not suppressed anymore. Any change made while it was
+			// suppressed has been ignored, so spec and labels can not be compared to previous version.
+			if oldHC.GetAnnotations()[SuppressedHealthCheckAnnotation] == "true" {
+				log.V(logs.LogVerbose).Info(
+					"HealthCheck is not suppressed anymore. Will attempt to reconcile associated ClusterHealthChecks.")
+				return true
+			}
+
 			// return true if HealthCheck Spec has changed
 			if !reflect.DeepEqual(oldHC.Spec, newHC.Spec) {
+				changedFields := getChangedSpecFields(oldHC.Spec, newHC.Spec)
+				for i := range changedFields {
+					log.V(logs.LogVerbose).Info(fmt.Sprintf("HealthCheck spec.%s changed", changedFields[i]))
+				}
 				log.V(logs.LogVerbose).Info(
 					"HealthCheck changed. Will attempt to reconcile associated ClusterHealthChecks.")
 				return true
 			}
 
+			// return true if HealthCheck labels have changed
+			if !reflect.DeepEqual(oldHC.Labels, newHC.Labels) {
+				log.V(logs.LogVerbose).Info(
+					"HealthCheck labels changed. Will attempt to reconcile associated ClusterHealthChecks.")
+				return true
+			}
+
 			// otherwise, return false
 			log.V(logs.LogVerbose).Info(
 				"HealthCheck did not match expected conditions.  Will not attempt to reconcile associated ClusterHealthChecks.")
@@ -432,3 +703,37 @@ func HealthCheckPredicates(logger logr.Logger) predicate.Funcs {
 		},
 	}
 }
+
+// getChangedSpecFields returns the (json) names of the exported top-level fields which differ
+// between oldSpec and newSpec. Both must be structs (or pointers to structs) of same type.
+// Nil is returned otherwise.
+func getChangedSpecFields(oldSpec, newSpec interface{}) []string {
+	oldValue := reflect.Indirect(reflect.ValueOf(oldSpec))
+	newValue := reflect.Indirect(reflect.ValueOf(newSpec))
+
+	if oldValue.Kind() != reflect.Struct || newValue.Kind() != reflect.Struct ||
+		oldValue.Type() != newValue.Type() {
+
+		return nil
+	}
+
+	changedFields := make([]string, 0)
+	for i := 0; i < oldValue.NumField(); i++ {
+		field := oldValue.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		if reflect.DeepEqual(oldValue.Field(i).Interface(), newValue.Field(i).Interface()) {
+			continue
+		}
+
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "" {
+			name = field.Name
+		}
+		changedFields = append(changedFields, name)
+	}
+
+	return changedFields
+}
@@ -17,19 +17,27 @@ limitations under the License.
 package controllers_test
 
 import (
+	"strings"
+	"time"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
 	"github.com/go-logr/logr"
+	"github.com/go-logr/logr/funcr"
 	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/klog/v2/textlogger"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 
 	configv1alpha1 "github.com/projectsveltos/addon-controller/api/v1alpha1"
 	"github.com/projectsveltos/healthcheck-manager/controllers"
+	"github.com/projectsveltos/healthcheck-manager/controllers/testhelpers"
 	libsveltosv1alpha1 "github.com/projectsveltos/libsveltos/api/v1alpha1"
+	logs "github.com/projectsveltos/libsveltos/lib/logsettings"
 )
 
 const (
@@ -43,12 +51,10 @@ var _ = Describe("ClusterHealthCheck Predicates: SvelotsClusterPredicates", func
 
 	BeforeEach(func() {
 		logger = textlogger.NewLogger(textlogger.NewConfig(textlogger.Verbosity(1)))
-		cluster = &libsveltosv1alpha1.SveltosCluster{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      upstreamClusterNamePrefix + randomString(),
-				Namespace: predicates + randomString(),
-			},
-		}
+		cluster = testhelpers.NewSveltosCluster().
+			WithNamespace(predicates + randomString()).
+			WithName(upstreamClusterNamePrefix + randomString()).
+			Build()
 	})
 
 	It("Create reprocesses when sveltos Cluster is unpaused", func() {
@@ -76,6 +82,33 @@ var _ = Describe("ClusterHealthCheck Predicates: SvelotsClusterPredicates", func
 		result := clusterPredicate.Create(e)
 		Expect(result).To(BeFalse())
 	})
+	It("Create reprocesses when sveltos Cluster is created ready", func() {
+		clusterPredicate := controllers.SveltosClusterPredicates(logger)
+
+		testCases := []struct {
+			paused bool
+			ready  bool
+		}{
+			{paused: true, ready: true},
+			{paused: false, ready: true},
+			{paused: false, ready: false},
+		}
+
+		for i := range testCases {
+			sveltosCluster := testhelpers.NewSveltosCluster().
+				WithNamespace(cluster.Namespace).
+				WithName(cluster.Name).
+				WithPaused(testCases[i].paused).
+				WithReady(testCases[i].ready).
+				Build()
+
+			e := event.CreateEvent{
+				Object: sveltosCluster,
+			}
+
+			Expect(clusterPredicate.Create(e)).To(BeTrue())
+		}
+	})
 	It("Delete does reprocess ", func() {
 		clusterPredicate := controllers.SveltosClusterPredicates(logger)
 
@@ -86,19 +119,29 @@ var _ = Describe("ClusterHealthCheck Predicates: SvelotsClusterPredicates", func
 		result := clusterPredicate.Delete(e)
 		Expect(result).To(BeTrue())
 	})
+	It("Delete logs sveltos Cluster UID", func() {
+		var messages []string
+		captureLogger := funcr.New(func(prefix, args string) {
+			messages = append(messages, args)
+		}, funcr.Options{Verbosity: logs.LogVerbose})
+
+		cluster.UID = types.UID(randomString())
+		clusterPredicate := controllers.SveltosClusterPredicates(captureLogger)
+
+		Expect(clusterPredicate.Delete(event.DeleteEvent{Object: cluster})).To(BeTrue())
+		Expect(messages).To(ContainElement(ContainSubstring(string(cluster.UID))))
+	})
 	It("Update reprocesses when sveltos Cluster paused changes from true to false", func() {
 		clusterPredicate := controllers.SveltosClusterPredicates(logger)
 
 		cluster.Spec.Paused = false
 
-		oldCluster := &libsveltosv1alpha1.SveltosCluster{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      cluster.Name,
-				Namespace: cluster.Namespace,
-			},
-		}
-		oldCluster.Spec.Paused = true
-		oldCluster.Annotations = map[string]string{clusterv1.PausedAnnotation: "true"}
+		oldCluster := testhelpers.NewSveltosCluster().
+			WithNamespace(cluster.Namespace).
+			WithName(cluster.Name).
+			WithPaused(true).
+			WithAnnotations(map[string]string{clusterv1.PausedAnnotation: "true"}).
+			Build()
 
 		e := event.UpdateEvent{
 			ObjectNew: cluster,
@@ -113,13 +156,11 @@ var _ = Describe("ClusterHealthCheck Predicates: SvelotsClusterPredicates", func
 
 		cluster.Spec.Paused = true
 		cluster.Annotations = map[string]string{clusterv1.PausedAnnotation: "true"}
-		oldCluster := &libsveltosv1alpha1.SveltosCluster{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      cluster.Name,
-				Namespace: cluster.Namespace,
-			},
-		}
-		oldCluster.Spec.Paused = false
+		oldCluster := testhelpers.NewSveltosCluster().
+			WithNamespace(cluster.Namespace).
+			WithName(cluster.Name).
+			WithPaused(false).
+			Build()
 
 		e := event.UpdateEvent{
 			ObjectNew: cluster,
@@ -133,13 +174,11 @@ var _ = Describe("ClusterHealthCheck Predicates: SvelotsClusterPredicates", func
 		clusterPredicate := controllers.SveltosClusterPredicates(logger)
 
 		cluster.Spec.Paused = false
-		oldCluster := &libsveltosv1alpha1.SveltosCluster{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      cluster.Name,
-				Namespace: cluster.Namespace,
-			},
-		}
-		oldCluster.Spec.Paused = false
+		oldCluster := testhelpers.NewSveltosCluster().
+			WithNamespace(cluster.Namespace).
+			WithName(cluster.Name).
+			WithPaused(false).
+			Build()
 
 		e := event.UpdateEvent{
 			ObjectNew: cluster,
@@ -154,13 +193,11 @@ var _ = Describe("ClusterHealthCheck Predicates: SvelotsClusterPredicates", func
 
 		cluster.Labels = map[string]string{"department": "eng"}
 
-		oldCluster := &libsveltosv1alpha1.SveltosCluster{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      cluster.Name,
-				Namespace: cluster.Namespace,
-				Labels:    map[string]string{},
-			},
-		}
+		oldCluster := testhelpers.NewSveltosCluster().
+			WithNamespace(cluster.Namespace).
+			WithName(cluster.Name).
+			WithLabels(map[string]string{}).
+			Build()
 
 		e := event.UpdateEvent{
 			ObjectNew: cluster,
@@ -175,24 +212,118 @@ var _ = Describe("ClusterHealthCheck Predicates: SvelotsClusterPredicates", func
 
 		cluster.Status.Ready = true
 
-		oldCluster := &libsveltosv1alpha1.SveltosCluster{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      cluster.Name,
-				Namespace: cluster.Namespace,
-				Labels:    map[string]string{},
-			},
-			Status: libsveltosv1alpha1.SveltosClusterStatus{
-				Ready: false,
-			},
+		oldCluster := testhelpers.NewSveltosCluster().
+			WithNamespace(cluster.Namespace).
+			WithName(cluster.Name).
+			WithLabels(map[string]string{}).
+			WithReady(false).
+			Build()
+
+		e := event.UpdateEvent{
+			ObjectNew: cluster,
+			ObjectOld: oldCluster,
 		}
 
+		result := clusterPredicate.Update(e)
+		Expect(result).To(BeTrue())
+	})
+	It("Update does not reprocess when only sveltos Cluster Status changes and generation is unchanged", func() {
+		clusterPredicate := controllers.SveltosClusterPredicates(logger)
+
+		cluster.Generation = 3
+		cluster.Status.Ready = true
+		cluster.Status.LastReconciledTokenRequestAt = randomString()
+
+		oldCluster := cluster.DeepCopy()
+		oldCluster.Status.LastReconciledTokenRequestAt = randomString()
+
 		e := event.UpdateEvent{
 			ObjectNew: cluster,
 			ObjectOld: oldCluster,
 		}
 
 		result := clusterPredicate.Update(e)
+		Expect(result).To(BeFalse())
+	})
+	It("Update reprocesses changes not bumping generation: Status Ready, labels and unpause", func() {
+		clusterPredicate := controllers.SveltosClusterPredicates(logger)
+
+		cluster.Generation = 3
+		oldCluster := cluster.DeepCopy()
+
+		By("Status.Ready changing from false to true")
+		cluster.Status.Ready = true
+		result := clusterPredicate.Update(event.UpdateEvent{ObjectNew: cluster, ObjectOld: oldCluster})
+		Expect(result).To(BeTrue())
+
+		By("labels changing")
+		oldCluster = cluster.DeepCopy()
+		cluster.Labels = map[string]string{"department": "eng"}
+		result = clusterPredicate.Update(event.UpdateEvent{ObjectNew: cluster, ObjectOld: oldCluster})
+		Expect(result).To(BeTrue())
+
+		By("Spec.Paused changing from true to false")
+		oldCluster = cluster.DeepCopy()
+		oldCluster.Spec.Paused = true
+		result = clusterPredicate.Update(event.UpdateEvent{ObjectNew: cluster, ObjectOld: oldCluster})
+		Expect(result).To(BeTrue())
+	})
+	It("Update reprocesses when sveltos Cluster skip annotation is added or removed", func() {
+		clusterPredicate := controllers.SveltosClusterPredicates(logger)
+
+		cluster.Generation = 3
+		oldCluster := cluster.DeepCopy()
+
+		By("skip annotation being added")
+		cluster.Annotations = map[string]string{controllers.SkipClusterAnnotation: "true"}
+		result := clusterPredicate.Update(event.UpdateEvent{ObjectNew: cluster, ObjectOld: oldCluster})
+		Expect(result).To(BeTrue())
+
+		By("skip annotation being removed")
+		oldCluster = cluster.DeepCopy()
+		cluster.Annotations = map[string]string{}
+		result = clusterPredicate.Update(event.UpdateEvent{ObjectNew: cluster, ObjectOld: oldCluster})
 		Expect(result).To(BeTrue())
+
+		By("unrelated annotation changing")
+		oldCluster = cluster.DeepCopy()
+		cluster.Annotations = map[string]string{randomString(): randomString()}
+		result = clusterPredicate.Update(event.UpdateEvent{ObjectNew: cluster, ObjectOld: oldCluster})
+		Expect(result).To(BeFalse())
+	})
+	It("Update reprocesses when sveltos Cluster TokenRequestRenewalOption changes", func() {
+		clusterPredicate := controllers.SveltosClusterPredicates(logger)
+
+		cluster.Generation = 3
+		cluster.Spec.TokenRequestRenewalOption = &libsveltosv1alpha1.TokenRequestRenewalOption{
+			RenewTokenRequestInterval: metav1.Duration{Duration: time.Hour},
+		}
+
+		oldCluster := cluster.DeepCopy()
+		oldCluster.Generation = 2
+		oldCluster.Spec.TokenRequestRenewalOption.RenewTokenRequestInterval = metav1.Duration{Duration: time.Minute}
+
+		result := clusterPredicate.Update(event.UpdateEvent{ObjectNew: cluster, ObjectOld: oldCluster})
+		Expect(result).To(BeTrue())
+
+		By("TokenRequestRenewalOption being set")
+		oldCluster.Spec.TokenRequestRenewalOption = nil
+		result = clusterPredicate.Update(event.UpdateEvent{ObjectNew: cluster, ObjectOld: oldCluster})
+		Expect(result).To(BeTrue())
+	})
+	It("Update does not reprocess when sveltos Cluster TokenRequestRenewalOption has not changed", func() {
+		clusterPredicate := controllers.SveltosClusterPredicates(logger)
+
+		cluster.Generation = 3
+		cluster.Spec.TokenRequestRenewalOption = &libsveltosv1alpha1.TokenRequestRenewalOption{
+			RenewTokenRequestInterval: metav1.Duration{Duration: time.Hour},
+		}
+
+		oldCluster := cluster.DeepCopy()
+		oldCluster.Generation = 2
+
+		result := clusterPredicate.Update(event.UpdateEvent{ObjectNew: cluster, ObjectOld: oldCluster})
+		Expect(result).To(BeFalse())
 	})
 })
 
@@ -292,6 +423,150 @@ var _ = Describe("ClusterHealthCheck Predicates: ClusterSummaryPredicates", func
 		result := clusterSummaryPredicate.Update(e)
 		Expect(result).To(BeFalse())
 	})
+
+	It("Update reprocesses when ClusterSummary status DeployedGVKs has a new GVK", func() {
+		clusterSummaryPredicate := controllers.ClusterSummaryPredicates(logger)
+
+		clusterSummary.Status.DeployedGVKs = []configv1alpha1.FeatureDeploymentInfo{
+			{
+				FeatureID:                configv1alpha1.FeatureResources,
+				DeployedGroupVersionKind: []string{"Deployment.v1.apps", "Certificate.v1.cert-manager.io"},
+			},
+		}
+
+		oldClusterSummary := &configv1alpha1.ClusterSummary{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      clusterSummary.Name,
+				Namespace: clusterSummary.Namespace,
+			},
+			Status: configv1alpha1.ClusterSummaryStatus{
+				DeployedGVKs: []configv1alpha1.FeatureDeploymentInfo{
+					{
+						FeatureID:                configv1alpha1.FeatureResources,
+						DeployedGroupVersionKind: []string{"Deployment.v1.apps"},
+					},
+				},
+			},
+		}
+
+		e := event.UpdateEvent{
+			ObjectNew: clusterSummary,
+			ObjectOld: oldClusterSummary,
+		}
+
+		result := clusterSummaryPredicate.Update(e)
+		Expect(result).To(BeTrue())
+	})
+
+	It("Update reprocesses when ClusterSummary status DeployedGVKs has a GVK removed", func() {
+		clusterSummaryPredicate := controllers.ClusterSummaryPredicates(logger)
+
+		clusterSummary.Status.DeployedGVKs = []configv1alpha1.FeatureDeploymentInfo{
+			{
+				FeatureID:                configv1alpha1.FeatureResources,
+				DeployedGroupVersionKind: []string{"Deployment.v1.apps"},
+			},
+		}
+
+		oldClusterSummary := &configv1alpha1.ClusterSummary{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      clusterSummary.Name,
+				Namespace: clusterSummary.Namespace,
+			},
+			Status: configv1alpha1.ClusterSummaryStatus{
+				DeployedGVKs: []configv1alpha1.FeatureDeploymentInfo{
+					{
+						FeatureID:                configv1alpha1.FeatureResources,
+						DeployedGroupVersionKind: []string{"Deployment.v1.apps", "Certificate.v1.cert-manager.io"},
+					},
+				},
+			},
+		}
+
+		e := event.UpdateEvent{
+			ObjectNew: clusterSummary,
+			ObjectOld: oldClusterSummary,
+		}
+
+		result := clusterSummaryPredicate.Update(e)
+		Expect(result).To(BeTrue())
+	})
+
+	It("Update reprocesses when ClusterSummary status DeployedGVKs has a GVK version changed", func() {
+		clusterSummaryPredicate := controllers.ClusterSummaryPredicates(logger)
+
+		clusterSummary.Status.DeployedGVKs = []configv1alpha1.FeatureDeploymentInfo{
+			{
+				FeatureID:                configv1alpha1.FeatureHelm,
+				DeployedGroupVersionKind: []string{"Certificate.v1.cert-manager.io"},
+			},
+		}
+
+		oldClusterSummary := &configv1alpha1.ClusterSummary{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      clusterSummary.Name,
+				Namespace: clusterSummary.Namespace,
+			},
+			Status: configv1alpha1.ClusterSummaryStatus{
+				DeployedGVKs: []configv1alpha1.FeatureDeploymentInfo{
+					{
+						FeatureID:                configv1alpha1.FeatureHelm,
+						DeployedGroupVersionKind: []string{"Certificate.v1alpha2.cert-manager.io"},
+					},
+				},
+			},
+		}
+
+		e := event.UpdateEvent{
+			ObjectNew: clusterSummary,
+			ObjectOld: oldClusterSummary,
+		}
+
+		result := clusterSummaryPredicate.Update(e)
+		Expect(result).To(BeTrue())
+	})
+
+	It("Update and Delete do not reprocess when ClusterSummary is not in a managed namespace", func() {
+		clusterSummaryPredicate := controllers.ClusterSummaryPredicates(logger, randomString(), randomString())
+
+		clusterSummary.Status.FeatureSummaries = []configv1alpha1.FeatureSummary{
+			{
+				Status:    configv1alpha1.FeatureStatusProvisioned,
+				FeatureID: configv1alpha1.FeatureHelm,
+			},
+		}
+
+		oldClusterSummary := &configv1alpha1.ClusterSummary{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      clusterSummary.Name,
+				Namespace: clusterSummary.Namespace,
+			},
+		}
+
+		updateEvent := event.UpdateEvent{
+			ObjectNew: clusterSummary,
+			ObjectOld: oldClusterSummary,
+		}
+		Expect(clusterSummaryPredicate.Update(updateEvent)).To(BeFalse())
+
+		deleteEvent := event.DeleteEvent{
+			Object: clusterSummary,
+		}
+		Expect(clusterSummaryPredicate.Delete(deleteEvent)).To(BeFalse())
+
+		clusterSummaryPredicate = controllers.ClusterSummaryPredicates(logger, randomString(), clusterSummary.Namespace)
+		Expect(clusterSummaryPredicate.Update(updateEvent)).To(BeTrue())
+		Expect(clusterSummaryPredicate.Delete(deleteEvent)).To(BeTrue())
+	})
+
+	It("Empty managed namespaces preserve all namespaces behavior", func() {
+		clusterSummaryPredicate := controllers.ClusterSummaryPredicates(logger, []string{}...)
+
+		e := event.DeleteEvent{
+			Object: clusterSummary,
+		}
+		Expect(clusterSummaryPredicate.Delete(e)).To(BeTrue())
+	})
 })
 
 var _ = Describe("ClusterHealthCheck Predicates: ClusterPredicates", func() {
@@ -302,12 +577,10 @@ var _ = Describe("ClusterHealthCheck Predicates: ClusterPredicates", func() {
 
 	BeforeEach(func() {
 		logger = textlogger.NewLogger(textlogger.NewConfig(textlogger.Verbosity(1)))
-		cluster = &clusterv1.Cluster{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      upstreamClusterNamePrefix + randomString(),
-				Namespace: predicates + randomString(),
-			},
-		}
+		cluster = testhelpers.NewCluster().
+			WithNamespace(predicates + randomString()).
+			WithName(upstreamClusterNamePrefix + randomString()).
+			Build()
 	})
 
 	It("Create reprocesses when v1Cluster is unpaused", func() {
@@ -327,25 +600,54 @@ var _ = Describe("ClusterHealthCheck Predicates: ClusterPredicates", func() {
 		result := clusterPredicate.Create(event.TypedCreateEvent[*clusterv1.Cluster]{Object: cluster})
 		Expect(result).To(BeFalse())
 	})
+	It("Create reprocesses when v1Cluster is paused and RespawnPausedClusters is set", func() {
+		clusterPredicate := controllers.ClusterPredicate{Logger: logger, RespawnPausedClusters: true}
+
+		cluster = testhelpers.NewCluster().
+			WithNamespace(cluster.Namespace).
+			WithName(cluster.Name).
+			WithPaused(true).
+			Build()
+
+		result := clusterPredicate.Create(event.TypedCreateEvent[*clusterv1.Cluster]{Object: cluster})
+		Expect(result).To(BeTrue())
+	})
+	It("Create reprocesses when v1Cluster is unpaused and RespawnPausedClusters is set", func() {
+		clusterPredicate := controllers.ClusterPredicate{Logger: logger, RespawnPausedClusters: true}
+
+		cluster.Spec.Paused = false
+
+		result := clusterPredicate.Create(event.TypedCreateEvent[*clusterv1.Cluster]{Object: cluster})
+		Expect(result).To(BeTrue())
+	})
 	It("Delete does reprocess ", func() {
 		clusterPredicate := controllers.ClusterPredicate{Logger: logger}
 
 		result := clusterPredicate.Delete(event.TypedDeleteEvent[*clusterv1.Cluster]{Object: cluster})
 		Expect(result).To(BeTrue())
 	})
+	It("Delete logs v1Cluster UID", func() {
+		var messages []string
+		captureLogger := funcr.New(func(prefix, args string) {
+			messages = append(messages, args)
+		}, funcr.Options{Verbosity: logs.LogVerbose})
+
+		cluster.UID = types.UID(randomString())
+		clusterPredicate := controllers.ClusterPredicate{Logger: captureLogger}
+
+		Expect(clusterPredicate.Delete(event.TypedDeleteEvent[*clusterv1.Cluster]{Object: cluster})).To(BeTrue())
+		Expect(messages).To(ContainElement(ContainSubstring(string(cluster.UID))))
+	})
 	It("Update reprocesses when v1Cluster paused changes from true to false", func() {
 		clusterPredicate := controllers.ClusterPredicate{Logger: logger}
 
 		cluster.Spec.Paused = false
 
-		oldCluster := &clusterv1.Cluster{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      cluster.Name,
-				Namespace: cluster.Namespace,
-			},
-		}
-		oldCluster.Spec.Paused = true
-		oldCluster.Annotations = map[string]string{clusterv1.PausedAnnotation: "true"}
+		oldCluster := testhelpers.NewCluster().
+			WithNamespace(cluster.Namespace).
+			WithName(cluster.Name).
+			WithPaused(true).
+			Build()
 
 		result := clusterPredicate.Update(event.TypedUpdateEvent[*clusterv1.Cluster]{
 			ObjectNew: cluster, ObjectOld: oldCluster})
@@ -356,13 +658,11 @@ var _ = Describe("ClusterHealthCheck Predicates: ClusterPredicates", func() {
 
 		cluster.Spec.Paused = true
 		cluster.Annotations = map[string]string{clusterv1.PausedAnnotation: "true"}
-		oldCluster := &clusterv1.Cluster{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      cluster.Name,
-				Namespace: cluster.Namespace,
-			},
-		}
-		oldCluster.Spec.Paused = false
+		oldCluster := testhelpers.NewCluster().
+			WithNamespace(cluster.Namespace).
+			WithName(cluster.Name).
+			WithPaused(false).
+			Build()
 
 		result := clusterPredicate.Update(event.TypedUpdateEvent[*clusterv1.Cluster]{
 			ObjectNew: cluster, ObjectOld: oldCluster})
@@ -372,13 +672,11 @@ var _ = Describe("ClusterHealthCheck Predicates: ClusterPredicates", func() {
 		clusterPredicate := controllers.ClusterPredicate{Logger: logger}
 
 		cluster.Spec.Paused = false
-		oldCluster := &clusterv1.Cluster{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      cluster.Name,
-				Namespace: cluster.Namespace,
-			},
-		}
-		oldCluster.Spec.Paused = false
+		oldCluster := testhelpers.NewCluster().
+			WithNamespace(cluster.Namespace).
+			WithName(cluster.Name).
+			WithPaused(false).
+			Build()
 
 		result := clusterPredicate.Update(event.TypedUpdateEvent[*clusterv1.Cluster]{
 			ObjectNew: cluster, ObjectOld: oldCluster})
@@ -389,54 +687,313 @@ var _ = Describe("ClusterHealthCheck Predicates: ClusterPredicates", func() {
 
 		cluster.Labels = map[string]string{"department": "eng"}
 
-		oldCluster := &clusterv1.Cluster{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      cluster.Name,
-				Namespace: cluster.Namespace,
-				Labels:    map[string]string{},
-			},
-		}
+		oldCluster := testhelpers.NewCluster().
+			WithNamespace(cluster.Namespace).
+			WithName(cluster.Name).
+			WithLabels(map[string]string{}).
+			Build()
 
 		result := clusterPredicate.Update(event.TypedUpdateEvent[*clusterv1.Cluster]{
 			ObjectNew: cluster, ObjectOld: oldCluster})
 		Expect(result).To(BeTrue())
 	})
-})
+	It("Update reprocesses when v1Cluster skip annotation is added or removed", func() {
+		clusterPredicate := controllers.ClusterPredicate{Logger: logger}
 
-var _ = Describe("ClusterHealthCheck Predicates: MachinePredicates", func() {
-	var logger logr.Logger
-	var machine *clusterv1.Machine
+		oldCluster := cluster.DeepCopy()
 
-	const upstreamMachineNamePrefix = "machine-predicates-"
+		By("skip annotation being added")
+		cluster.Annotations = map[string]string{controllers.SkipClusterAnnotation: "true"}
+		result := clusterPredicate.Update(event.TypedUpdateEvent[*clusterv1.Cluster]{
+			ObjectNew: cluster, ObjectOld: oldCluster})
+		Expect(result).To(BeTrue())
 
-	BeforeEach(func() {
-		logger = textlogger.NewLogger(textlogger.NewConfig(textlogger.Verbosity(1)))
-		machine = &clusterv1.Machine{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      upstreamMachineNamePrefix + randomString(),
-				Namespace: predicates + randomString(),
-			},
-		}
+		By("skip annotation being removed")
+		oldCluster = cluster.DeepCopy()
+		cluster.Annotations = map[string]string{}
+		result = clusterPredicate.Update(event.TypedUpdateEvent[*clusterv1.Cluster]{
+			ObjectNew: cluster, ObjectOld: oldCluster})
+		Expect(result).To(BeTrue())
 	})
+	It("Update reprocesses when v1Cluster ControlPlaneReady changes", func() {
+		clusterPredicate := controllers.ClusterPredicate{Logger: logger}
 
-	It("Create reprocesses when v1Machine is Running", func() {
-		machinePredicate := controllers.MachinePredicate{Logger: logger}
+		oldCluster := testhelpers.NewCluster().WithNamespace(cluster.Namespace).WithName(cluster.Name).Build()
 
-		machine.Status.Phase = string(clusterv1.MachinePhaseRunning)
+		By("control plane becomes ready")
+		cluster.Status.ControlPlaneReady = true
+		oldCluster.Status.ControlPlaneReady = false
+		result := clusterPredicate.Update(event.TypedUpdateEvent[*clusterv1.Cluster]{
+			ObjectNew: cluster, ObjectOld: oldCluster})
+		Expect(result).To(BeTrue())
 
-		result := machinePredicate.Create(event.TypedCreateEvent[*clusterv1.Machine]{Object: machine})
+		By("control plane loses readiness")
+		cluster.Status.ControlPlaneReady = false
+		oldCluster.Status.ControlPlaneReady = true
+		result = clusterPredicate.Update(event.TypedUpdateEvent[*clusterv1.Cluster]{
+			ObjectNew: cluster, ObjectOld: oldCluster})
 		Expect(result).To(BeTrue())
 	})
-	It("Create does not reprocess when v1Machine is not Running", func() {
-		machinePredicate := controllers.MachinePredicate{Logger: logger}
+	It("Update does not reprocess when v1Cluster ControlPlaneReady has not changed", func() {
+		clusterPredicate := controllers.ClusterPredicate{Logger: logger}
 
-		result := machinePredicate.Create(event.TypedCreateEvent[*clusterv1.Machine]{Object: machine})
-		Expect(result).To(BeFalse())
-	})
-	It("Delete does not reprocess ", func() {
-		machinePredicate := controllers.MachinePredicate{Logger: logger}
+		cluster.Status.ControlPlaneReady = true
+		oldCluster := testhelpers.NewCluster().
+			WithNamespace(cluster.Namespace).
+			WithName(cluster.Name).
+			WithControlPlaneReady(true).
+			Build()
 
-		result := machinePredicate.Delete(event.TypedDeleteEvent[*clusterv1.Machine]{Object: machine})
+		result := clusterPredicate.Update(event.TypedUpdateEvent[*clusterv1.Cluster]{
+			ObjectNew: cluster, ObjectOld: oldCluster})
+		Expect(result).To(BeFalse())
+
+		By("labels change while control plane readiness does not")
+		cluster.Labels = map[string]string{"department": "eng"}
+		result = clusterPredicate.Update(event.TypedUpdateEvent[*clusterv1.Cluster]{
+			ObjectNew: cluster, ObjectOld: oldCluster})
+		Expect(result).To(BeTrue())
+	})
+	It("Update reprocesses when v1Cluster ControlPlaneRef changes", func() {
+		clusterPredicate := controllers.ClusterPredicate{Logger: logger}
+
+		oldCluster := cluster.DeepCopy()
+
+		By("control plane reference is added")
+		cluster.Spec.ControlPlaneRef = &corev1.ObjectReference{
+			APIVersion: "controlplane.cluster.x-k8s.io/v1beta1",
+			Kind:       "KubeadmControlPlane",
+			Namespace:  cluster.Namespace,
+			Name:       randomString(),
+		}
+		result := clusterPredicate.Update(event.TypedUpdateEvent[*clusterv1.Cluster]{
+			ObjectNew: cluster, ObjectOld: oldCluster})
+		Expect(result).To(BeTrue())
+
+		By("control plane reference points to a different control plane")
+		oldCluster = cluster.DeepCopy()
+		cluster.Spec.ControlPlaneRef.Name = randomString()
+		result = clusterPredicate.Update(event.TypedUpdateEvent[*clusterv1.Cluster]{
+			ObjectNew: cluster, ObjectOld: oldCluster})
+		Expect(result).To(BeTrue())
+	})
+	It("Update does not reprocess when v1Cluster ControlPlaneRef has not changed", func() {
+		clusterPredicate := controllers.ClusterPredicate{Logger: logger}
+
+		cluster = testhelpers.NewCluster().
+			WithNamespace(cluster.Namespace).
+			WithName(cluster.Name).
+			WithControlPlaneRef(&corev1.ObjectReference{
+				APIVersion: "controlplane.cluster.x-k8s.io/v1beta1",
+				Kind:       "KubeadmControlPlane",
+				Namespace:  cluster.Namespace,
+				Name:       randomString(),
+			}).
+			Build()
+		oldCluster := cluster.DeepCopy()
+
+		result := clusterPredicate.Update(event.TypedUpdateEvent[*clusterv1.Cluster]{
+			ObjectNew: cluster, ObjectOld: oldCluster})
+		Expect(result).To(BeFalse())
+	})
+	It("Update reprocesses when v1Cluster Topology changes", func() {
+		clusterPredicate := controllers.ClusterPredicate{Logger: logger}
+
+		cluster = testhelpers.NewCluster().
+			WithNamespace(cluster.Namespace).
+			WithName(cluster.Name).
+			WithTopology(&clusterv1.Topology{
+				Class:   randomString(),
+				Version: "v1.29.0",
+				Variables: []clusterv1.ClusterVariable{
+					{Name: randomString(), Value: apiextensionsv1.JSON{Raw: []byte(`"small"`)}},
+				},
+			}).
+			Build()
+
+		By("topology version is bumped")
+		oldCluster := cluster.DeepCopy()
+		cluster.Spec.Topology.Version = "v1.30.0"
+		result := clusterPredicate.Update(event.TypedUpdateEvent[*clusterv1.Cluster]{
+			ObjectNew: cluster, ObjectOld: oldCluster})
+		Expect(result).To(BeTrue())
+
+		By("topology class changes")
+		oldCluster = cluster.DeepCopy()
+		cluster.Spec.Topology.Class = randomString()
+		result = clusterPredicate.Update(event.TypedUpdateEvent[*clusterv1.Cluster]{
+			ObjectNew: cluster, ObjectOld: oldCluster})
+		Expect(result).To(BeTrue())
+
+		By("topology variable value changes")
+		oldCluster = cluster.DeepCopy()
+		cluster.Spec.Topology.Variables[0].Value = apiextensionsv1.JSON{Raw: []byte(`"large"`)}
+		result = clusterPredicate.Update(event.TypedUpdateEvent[*clusterv1.Cluster]{
+			ObjectNew: cluster, ObjectOld: oldCluster})
+		Expect(result).To(BeTrue())
+
+		By("topology has not changed")
+		oldCluster = cluster.DeepCopy()
+		result = clusterPredicate.Update(event.TypedUpdateEvent[*clusterv1.Cluster]{
+			ObjectNew: cluster, ObjectOld: oldCluster})
+		Expect(result).To(BeFalse())
+	})
+	It("Update reprocesses when v1Cluster ObservedGeneration catches up with Generation", func() {
+		clusterPredicate := controllers.ClusterPredicate{Logger: logger}
+
+		cluster.Generation = 2
+		cluster.Status.ObservedGeneration = 2
+
+		oldCluster := cluster.DeepCopy()
+		oldCluster.Status.ObservedGeneration = 1
+
+		result := clusterPredicate.Update(event.TypedUpdateEvent[*clusterv1.Cluster]{
+			ObjectNew: cluster, ObjectOld: oldCluster})
+		Expect(result).To(BeTrue())
+	})
+	It("Update does not reprocess when v1Cluster ObservedGeneration was already caught up or is lagging", func() {
+		clusterPredicate := controllers.ClusterPredicate{Logger: logger}
+
+		By("already caught up")
+		cluster.Generation = 2
+		cluster.Status.ObservedGeneration = 2
+		oldCluster := cluster.DeepCopy()
+
+		result := clusterPredicate.Update(event.TypedUpdateEvent[*clusterv1.Cluster]{
+			ObjectNew: cluster, ObjectOld: oldCluster})
+		Expect(result).To(BeFalse())
+
+		By("lagging behind Generation")
+		cluster.Generation = 3
+		result = clusterPredicate.Update(event.TypedUpdateEvent[*clusterv1.Cluster]{
+			ObjectNew: cluster, ObjectOld: oldCluster})
+		Expect(result).To(BeFalse())
+	})
+})
+
+var _ = Describe("ClusterHealthCheck Predicates: ClusterConditionFilteredPredicates", func() {
+	var logger logr.Logger
+	var cluster *clusterv1.Cluster
+
+	const upstreamClusterNamePrefix = "cluster-condition-predicates-"
+
+	BeforeEach(func() {
+		logger = textlogger.NewLogger(textlogger.NewConfig(textlogger.Verbosity(1)))
+		cluster = testhelpers.NewCluster().
+			WithNamespace(predicates + randomString()).
+			WithName(upstreamClusterNamePrefix + randomString()).
+			Build()
+		cluster.Status.Conditions = []clusterv1.Condition{
+			{Type: clusterv1.ControlPlaneReadyCondition, Status: corev1.ConditionTrue},
+			{Type: clusterv1.InfrastructureReadyCondition, Status: corev1.ConditionTrue},
+		}
+	})
+
+	It("Update reprocesses when a relevant condition changes", func() {
+		conditionPredicate := controllers.ClusterConditionFilteredPredicates(
+			[]clusterv1.ConditionType{clusterv1.ControlPlaneReadyCondition}, logger)
+
+		oldCluster := cluster.DeepCopy()
+		cluster.Status.Conditions[0].Status = corev1.ConditionFalse
+
+		e := event.UpdateEvent{
+			ObjectNew: cluster,
+			ObjectOld: oldCluster,
+		}
+		Expect(conditionPredicate.Update(e)).To(BeTrue())
+
+		// Reason change only
+		oldCluster = cluster.DeepCopy()
+		cluster.Status.Conditions[0].Reason = randomString()
+
+		e = event.UpdateEvent{
+			ObjectNew: cluster,
+			ObjectOld: oldCluster,
+		}
+		Expect(conditionPredicate.Update(e)).To(BeTrue())
+	})
+
+	It("Update does not reprocess when an irrelevant condition changes", func() {
+		conditionPredicate := controllers.ClusterConditionFilteredPredicates(
+			[]clusterv1.ConditionType{clusterv1.ControlPlaneReadyCondition}, logger)
+
+		oldCluster := cluster.DeepCopy()
+		cluster.Status.Conditions[1].Status = corev1.ConditionFalse
+		cluster.Status.Conditions[1].Reason = randomString()
+		// Message is not considered
+		cluster.Status.Conditions[0].Message = randomString()
+
+		e := event.UpdateEvent{
+			ObjectNew: cluster,
+			ObjectOld: oldCluster,
+		}
+		Expect(conditionPredicate.Update(e)).To(BeFalse())
+	})
+
+	It("Update reprocesses when a relevant condition is added", func() {
+		conditionPredicate := controllers.ClusterConditionFilteredPredicates(
+			[]clusterv1.ConditionType{clusterv1.ControlPlaneInitializedCondition}, logger)
+
+		oldCluster := cluster.DeepCopy()
+		cluster.Status.Conditions = append(cluster.Status.Conditions,
+			clusterv1.Condition{Type: clusterv1.ControlPlaneInitializedCondition, Status: corev1.ConditionTrue})
+
+		e := event.UpdateEvent{
+			ObjectNew: cluster,
+			ObjectOld: oldCluster,
+		}
+		Expect(conditionPredicate.Update(e)).To(BeTrue())
+	})
+
+	It("Update reprocesses when a relevant condition is removed", func() {
+		conditionPredicate := controllers.ClusterConditionFilteredPredicates(
+			[]clusterv1.ConditionType{clusterv1.InfrastructureReadyCondition}, logger)
+
+		oldCluster := cluster.DeepCopy()
+		cluster.Status.Conditions = cluster.Status.Conditions[:1]
+
+		e := event.UpdateEvent{
+			ObjectNew: cluster,
+			ObjectOld: oldCluster,
+		}
+		Expect(conditionPredicate.Update(e)).To(BeTrue())
+	})
+})
+
+var _ = Describe("ClusterHealthCheck Predicates: MachinePredicates", func() {
+	var logger logr.Logger
+	var machine *clusterv1.Machine
+
+	const upstreamMachineNamePrefix = "machine-predicates-"
+
+	BeforeEach(func() {
+		logger = textlogger.NewLogger(textlogger.NewConfig(textlogger.Verbosity(1)))
+		machine = &clusterv1.Machine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      upstreamMachineNamePrefix + randomString(),
+				Namespace: predicates + randomString(),
+			},
+		}
+	})
+
+	It("Create reprocesses when v1Machine is Running", func() {
+		machinePredicate := controllers.MachinePredicate{Logger: logger}
+
+		machine.Status.Phase = string(clusterv1.MachinePhaseRunning)
+
+		result := machinePredicate.Create(event.TypedCreateEvent[*clusterv1.Machine]{Object: machine})
+		Expect(result).To(BeTrue())
+	})
+	It("Create does not reprocess when v1Machine is not Running", func() {
+		machinePredicate := controllers.MachinePredicate{Logger: logger}
+
+		result := machinePredicate.Create(event.TypedCreateEvent[*clusterv1.Machine]{Object: machine})
+		Expect(result).To(BeFalse())
+	})
+	It("Delete does not reprocess ", func() {
+		machinePredicate := controllers.MachinePredicate{Logger: logger}
+
+		result := machinePredicate.Delete(event.TypedDeleteEvent[*clusterv1.Machine]{Object: machine})
 		Expect(result).To(BeFalse())
 	})
 	It("Update reprocesses when v1Machine Phase changed from not running to running", func() {
@@ -483,6 +1040,56 @@ var _ = Describe("ClusterHealthCheck Predicates: MachinePredicates", func() {
 		}
 		oldMachine.Status.Phase = machine.Status.Phase
 
+		result := machinePredicate.Update(event.TypedUpdateEvent[*clusterv1.Machine]{
+			ObjectNew: machine, ObjectOld: oldMachine})
+		Expect(result).To(BeFalse())
+	})
+	It("Update reprocesses when watched v1Machine annotations change", func() {
+		const watchedKey = "cluster.x-k8s.io/machine-health-check-enabled"
+		machinePredicate := controllers.MachinePredicate{Logger: logger, WatchAnnotationKeys: []string{watchedKey}}
+		machine.Status.Phase = string(clusterv1.MachinePhaseRunning)
+
+		oldMachine := &clusterv1.Machine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      machine.Name,
+				Namespace: machine.Namespace,
+			},
+		}
+		oldMachine.Status.Phase = machine.Status.Phase
+
+		By("watched annotation key is added")
+		machine.Annotations = map[string]string{watchedKey: "true"}
+		result := machinePredicate.Update(event.TypedUpdateEvent[*clusterv1.Machine]{
+			ObjectNew: machine, ObjectOld: oldMachine})
+		Expect(result).To(BeTrue())
+
+		By("watched annotation value is changed")
+		oldMachine.Annotations = map[string]string{watchedKey: "false"}
+		result = machinePredicate.Update(event.TypedUpdateEvent[*clusterv1.Machine]{
+			ObjectNew: machine, ObjectOld: oldMachine})
+		Expect(result).To(BeTrue())
+
+		By("watched annotation key is removed")
+		oldMachine.Annotations = map[string]string{watchedKey: "true"}
+		machine.Annotations = nil
+		result = machinePredicate.Update(event.TypedUpdateEvent[*clusterv1.Machine]{
+			ObjectNew: machine, ObjectOld: oldMachine})
+		Expect(result).To(BeTrue())
+	})
+	It("Update does not reprocess when unrelated v1Machine annotations change", func() {
+		machinePredicate := controllers.MachinePredicate{Logger: logger,
+			WatchAnnotationKeys: []string{"cluster.x-k8s.io/machine-health-check-enabled"}}
+		machine.Status.Phase = string(clusterv1.MachinePhaseRunning)
+		machine.Annotations = map[string]string{randomString(): randomString()}
+
+		oldMachine := &clusterv1.Machine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      machine.Name,
+				Namespace: machine.Namespace,
+			},
+		}
+		oldMachine.Status.Phase = machine.Status.Phase
+
 		result := machinePredicate.Update(event.TypedUpdateEvent[*clusterv1.Machine]{
 			ObjectNew: machine, ObjectOld: oldMachine})
 		Expect(result).To(BeFalse())
@@ -589,6 +1196,104 @@ var _ = Describe("ClusterHealthCheck Predicates: HealthCheckReportPredicates", f
 		result := hcrPredicate.Update(e)
 		Expect(result).To(BeFalse())
 	})
+
+	It("Update reprocesses when a resource is added to HealthCheckReport", func() {
+		hcrPredicate := controllers.HealthCheckReportPredicates(logger)
+
+		oldHealthCheckReport := &libsveltosv1alpha1.HealthCheckReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      healthCheckReport.Name,
+				Namespace: healthCheckReport.Namespace,
+			},
+			Spec: libsveltosv1alpha1.HealthCheckReportSpec{
+				ResourceStatuses: []libsveltosv1alpha1.ResourceStatus{
+					{
+						ObjectRef:    corev1.ObjectReference{Kind: "Pod", Namespace: randomString(), Name: randomString()},
+						HealthStatus: libsveltosv1alpha1.HealthStatusHealthy,
+					},
+				},
+			},
+		}
+
+		healthCheckReport.Spec = *oldHealthCheckReport.Spec.DeepCopy()
+		healthCheckReport.Spec.ResourceStatuses = append(healthCheckReport.Spec.ResourceStatuses,
+			libsveltosv1alpha1.ResourceStatus{
+				ObjectRef:    corev1.ObjectReference{Kind: "Pod", Namespace: randomString(), Name: randomString()},
+				HealthStatus: libsveltosv1alpha1.HealthStatusHealthy,
+			})
+
+		e := event.UpdateEvent{
+			ObjectNew: healthCheckReport,
+			ObjectOld: oldHealthCheckReport,
+		}
+
+		result := hcrPredicate.Update(e)
+		Expect(result).To(BeTrue())
+	})
+
+	It("Update reprocesses when a resource is removed from HealthCheckReport", func() {
+		hcrPredicate := controllers.HealthCheckReportPredicates(logger)
+
+		oldHealthCheckReport := &libsveltosv1alpha1.HealthCheckReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      healthCheckReport.Name,
+				Namespace: healthCheckReport.Namespace,
+			},
+			Spec: libsveltosv1alpha1.HealthCheckReportSpec{
+				ResourceStatuses: []libsveltosv1alpha1.ResourceStatus{
+					{
+						ObjectRef:    corev1.ObjectReference{Kind: "Pod", Namespace: randomString(), Name: randomString()},
+						HealthStatus: libsveltosv1alpha1.HealthStatusHealthy,
+					},
+					{
+						ObjectRef:    corev1.ObjectReference{Kind: "Pod", Namespace: randomString(), Name: randomString()},
+						HealthStatus: libsveltosv1alpha1.HealthStatusHealthy,
+					},
+				},
+			},
+		}
+
+		healthCheckReport.Spec = *oldHealthCheckReport.Spec.DeepCopy()
+		healthCheckReport.Spec.ResourceStatuses = healthCheckReport.Spec.ResourceStatuses[:1]
+
+		e := event.UpdateEvent{
+			ObjectNew: healthCheckReport,
+			ObjectOld: oldHealthCheckReport,
+		}
+
+		result := hcrPredicate.Update(e)
+		Expect(result).To(BeTrue())
+	})
+
+	It("Update reprocesses when HealthCheckReport has same number of resources but different content", func() {
+		hcrPredicate := controllers.HealthCheckReportPredicates(logger)
+
+		oldHealthCheckReport := &libsveltosv1alpha1.HealthCheckReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      healthCheckReport.Name,
+				Namespace: healthCheckReport.Namespace,
+			},
+			Spec: libsveltosv1alpha1.HealthCheckReportSpec{
+				ResourceStatuses: []libsveltosv1alpha1.ResourceStatus{
+					{
+						ObjectRef:    corev1.ObjectReference{Kind: "Pod", Namespace: randomString(), Name: randomString()},
+						HealthStatus: libsveltosv1alpha1.HealthStatusHealthy,
+					},
+				},
+			},
+		}
+
+		healthCheckReport.Spec = *oldHealthCheckReport.Spec.DeepCopy()
+		healthCheckReport.Spec.ResourceStatuses[0].HealthStatus = libsveltosv1alpha1.HealthStatusDegraded
+
+		e := event.UpdateEvent{
+			ObjectNew: healthCheckReport,
+			ObjectOld: oldHealthCheckReport,
+		}
+
+		result := hcrPredicate.Update(e)
+		Expect(result).To(BeTrue())
+	})
 })
 
 var _ = Describe("ClusterHealthCheck Predicates: HealthCheckPredicates", func() {
@@ -599,11 +1304,9 @@ var _ = Describe("ClusterHealthCheck Predicates: HealthCheckPredicates", func()
 
 	BeforeEach(func() {
 		logger = textlogger.NewLogger(textlogger.NewConfig(textlogger.Verbosity(1)))
-		healthCheck = &libsveltosv1alpha1.HealthCheck{
-			ObjectMeta: metav1.ObjectMeta{
-				Name: upstreamClusterNamePrefix + randomString(),
-			},
-		}
+		healthCheck = testhelpers.NewHealthCheck().
+			WithName(upstreamClusterNamePrefix + randomString()).
+			Build()
 	})
 
 	It("Create will reprocesses", func() {
@@ -640,11 +1343,7 @@ var _ = Describe("ClusterHealthCheck Predicates: HealthCheckPredicates", func()
 			},
 		}
 
-		oldHealthCheck := &libsveltosv1alpha1.HealthCheck{
-			ObjectMeta: metav1.ObjectMeta{
-				Name: healthCheck.Name,
-			},
-		}
+		oldHealthCheck := testhelpers.NewHealthCheck().WithName(healthCheck.Name).Build()
 
 		e := event.UpdateEvent{
 			ObjectNew: healthCheck,
@@ -669,19 +1368,230 @@ var _ = Describe("ClusterHealthCheck Predicates: HealthCheckPredicates", func()
 			},
 		}
 
-		oldHealthCheck := &libsveltosv1alpha1.HealthCheck{
-			ObjectMeta: metav1.ObjectMeta{
-				Name: healthCheck.Name,
+		oldHealthCheck := testhelpers.NewHealthCheck().
+			WithName(healthCheck.Name).
+			WithResourceSelectors(healthCheck.Spec.ResourceSelectors...).
+			Build()
+
+		e := event.UpdateEvent{
+			ObjectNew: healthCheck,
+			ObjectOld: oldHealthCheck,
+		}
+
+		result := hcrPredicate.Update(e)
+		Expect(result).To(BeFalse())
+	})
+
+	It("Update logs each HealthCheck spec field which changed", func() {
+		oldHealthCheck := testhelpers.NewHealthCheck().
+			WithName(healthCheck.Name).
+			WithResourceSelector("apps", "v1", "Deployment").
+			WithEvaluateHealth(randomString()).
+			Build()
+
+		testCases := []struct {
+			field  string
+			modify func(hc *libsveltosv1alpha1.HealthCheck)
+		}{
+			{
+				field: "resourceSelectors",
+				modify: func(hc *libsveltosv1alpha1.HealthCheck) {
+					hc.Spec.ResourceSelectors[0].Namespace = randomString()
+				},
+			},
+			{
+				field: "evaluateHealth",
+				modify: func(hc *libsveltosv1alpha1.HealthCheck) {
+					hc.Spec.EvaluateHealth = randomString()
+				},
 			},
-			Spec: healthCheck.Spec,
+			{
+				field: "collectResources",
+				modify: func(hc *libsveltosv1alpha1.HealthCheck) {
+					hc.Spec.CollectResources = true
+				},
+			},
+		}
+
+		for i := range testCases {
+			var messages []string
+			captureLogger := funcr.New(func(prefix, args string) {
+				messages = append(messages, args)
+			}, funcr.Options{Verbosity: logs.LogVerbose})
+
+			newHealthCheck := oldHealthCheck.DeepCopy()
+			testCases[i].modify(newHealthCheck)
+
+			hcrPredicate := controllers.HealthCheckPredicates(captureLogger)
+			e := event.UpdateEvent{
+				ObjectNew: newHealthCheck,
+				ObjectOld: oldHealthCheck,
+			}
+			Expect(hcrPredicate.Update(e)).To(BeTrue())
+
+			changedMessages := 0
+			for j := range messages {
+				if strings.Contains(messages[j], "HealthCheck spec.") {
+					changedMessages++
+					Expect(messages[j]).To(ContainSubstring("HealthCheck spec." + testCases[i].field + " changed"))
+				}
+			}
+			Expect(changedMessages).To(Equal(1))
+		}
+	})
+
+	It("Update does not reprocess when new HealthCheck is suppressed", func() {
+		suppressed := map[string]string{controllers.SuppressedHealthCheckAnnotation: "true"}
+
+		testCases := []struct {
+			oldAnnotations map[string]string
+			newAnnotations map[string]string
+			result         bool
+		}{
+			{oldAnnotations: nil, newAnnotations: suppressed, result: false},
+			{oldAnnotations: suppressed, newAnnotations: nil, result: true},
+			{oldAnnotations: suppressed, newAnnotations: suppressed, result: false},
+			{oldAnnotations: nil, newAnnotations: nil, result: true},
 		}
 
+		hcrPredicate := controllers.HealthCheckPredicates(logger)
+
+		for i := range testCases {
+			oldHealthCheck := testhelpers.NewHealthCheck().
+				WithName(healthCheck.Name).
+				WithAnnotations(testCases[i].oldAnnotations).
+				Build()
+
+			// Spec changes, which would otherwise always trigger a reconciliation
+			newHealthCheck := testhelpers.NewHealthCheck().
+				WithName(healthCheck.Name).
+				WithAnnotations(testCases[i].newAnnotations).
+				WithEvaluateHealth(randomString()).
+				Build()
+
+			e := event.UpdateEvent{
+				ObjectNew: newHealthCheck,
+				ObjectOld: oldHealthCheck,
+			}
+
+			Expect(hcrPredicate.Update(e)).To(Equal(testCases[i].result))
+		}
+	})
+
+	It("Update reprocesses when only HealthCheck suppression annotation is removed", func() {
+		suppressed := map[string]string{controllers.SuppressedHealthCheckAnnotation: "true"}
+
+		testCases := []map[string]string{
+			nil,
+			{controllers.SuppressedHealthCheckAnnotation: "false"},
+		}
+
+		hcrPredicate := controllers.HealthCheckPredicates(logger)
+
+		for i := range testCases {
+			oldHealthCheck := testhelpers.NewHealthCheck().
+				WithName(healthCheck.Name).
+				WithAnnotations(suppressed).
+				Build()
+
+			// Spec and labels are unchanged
+			newHealthCheck := oldHealthCheck.DeepCopy()
+			newHealthCheck.Annotations = testCases[i]
+
+			e := event.UpdateEvent{
+				ObjectNew: newHealthCheck,
+				ObjectOld: oldHealthCheck,
+			}
+
+			Expect(hcrPredicate.Update(e)).To(BeTrue())
+		}
+	})
+
+	It("Update reprocesses when HealthCheck labels are added", func() {
+		hcrPredicate := controllers.HealthCheckPredicates(logger)
+
+		healthCheck.Labels = map[string]string{
+			randomString(): randomString(),
+		}
+
+		oldHealthCheck := testhelpers.NewHealthCheck().WithName(healthCheck.Name).Build()
+
 		e := event.UpdateEvent{
 			ObjectNew: healthCheck,
 			ObjectOld: oldHealthCheck,
 		}
 
 		result := hcrPredicate.Update(e)
-		Expect(result).To(BeFalse())
+		Expect(result).To(BeTrue())
+	})
+
+	It("Update reprocesses when HealthCheck labels are removed", func() {
+		hcrPredicate := controllers.HealthCheckPredicates(logger)
+
+		oldHealthCheck := testhelpers.NewHealthCheck().
+			WithName(healthCheck.Name).
+			WithLabels(map[string]string{
+				randomString(): randomString(),
+			}).
+			Build()
+
+		e := event.UpdateEvent{
+			ObjectNew: healthCheck,
+			ObjectOld: oldHealthCheck,
+		}
+
+		result := hcrPredicate.Update(e)
+		Expect(result).To(BeTrue())
+	})
+
+	It("Update reprocesses when HealthCheck label value changes", func() {
+		hcrPredicate := controllers.HealthCheckPredicates(logger)
+
+		key := randomString()
+		healthCheck.Labels = map[string]string{
+			key: randomString(),
+		}
+
+		oldHealthCheck := testhelpers.NewHealthCheck().
+			WithName(healthCheck.Name).
+			WithLabels(map[string]string{
+				key: randomString(),
+			}).
+			Build()
+
+		e := event.UpdateEvent{
+			ObjectNew: healthCheck,
+			ObjectOld: oldHealthCheck,
+		}
+
+		result := hcrPredicate.Update(e)
+		Expect(result).To(BeTrue())
+	})
+
+	It("getChangedSpecFields returns json names of changed exported fields only", func() {
+		type spec struct {
+			Exported   string `json:"exported,omitempty"`
+			unexported string
+			NoTag      int
+		}
+
+		oldSpec := spec{Exported: randomString(), unexported: randomString(), NoTag: 1}
+		newSpec := spec{Exported: randomString(), unexported: randomString(), NoTag: 2}
+
+		Expect(controllers.GetChangedSpecFields(oldSpec, newSpec)).To(ConsistOf("exported", "NoTag"))
+		Expect(controllers.GetChangedSpecFields(&oldSpec, &newSpec)).To(ConsistOf("exported", "NoTag"))
+
+		newSpec = oldSpec
+		newSpec.unexported = randomString()
+		Expect(controllers.GetChangedSpecFields(oldSpec, newSpec)).To(BeEmpty())
+	})
+
+	It("getChangedSpecFields returns nothing when arguments are not structs of same type", func() {
+		var nilSpec *libsveltosv1alpha1.HealthCheckSpec
+
+		Expect(controllers.GetChangedSpecFields(randomString(), randomString())).To(BeNil())
+		Expect(controllers.GetChangedSpecFields(nilSpec, &libsveltosv1alpha1.HealthCheckSpec{})).To(BeNil())
+		Expect(controllers.GetChangedSpecFields(libsveltosv1alpha1.HealthCheckSpec{},
+			libsveltosv1alpha1.HealthCheckReportSpec{})).To(BeNil())
 	})
 })
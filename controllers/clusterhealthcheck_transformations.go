@@ -51,9 +51,30 @@ func (r *ClusterHealthCheckReconciler) requeueClusterHealthCheckForHealthCheckRe
 		Kind: libsveltosv1alpha1.HealthCheckKind, Name: healthCheckReport.Spec.HealthCheckName}
 
 	// Get all ClusterHealthChecks referencing this HealthCheck
-	requests := make([]ctrl.Request, r.getReferenceMapForEntry(&healthCheckInfo).Len())
 	consumers := r.getReferenceMapForEntry(&healthCheckInfo).Items()
 
+	// A HealthCheckReport is about a single cluster. Only ClusterHealthChecks referencing the HealthCheck
+	// and matching that cluster own the report. This also holds when the report is deleted (for instance
+	// by an external garbage collector): only those ClusterHealthChecks need to re-evaluate.
+	if healthCheckReport.Spec.ClusterName != "" {
+		clusterInfo := getClusterInfoForHealthCheckReport(healthCheckReport)
+		if matching, ok := r.ClusterMap[*clusterInfo]; ok {
+			matchingNames := make(map[string]bool, matching.Len())
+			for _, chc := range matching.Items() {
+				matchingNames[chc.Name] = true
+			}
+
+			owners := make([]corev1.ObjectReference, 0, len(consumers))
+			for i := range consumers {
+				if matchingNames[consumers[i].Name] {
+					owners = append(owners, consumers[i])
+				}
+			}
+			consumers = owners
+		}
+	}
+
+	requests := make([]ctrl.Request, len(consumers))
 	for i := range consumers {
 		l := logger.WithValues("clusterHealthCheck", consumers[i].Name)
 		l.V(logs.LogDebug).Info("queuing ClusterHealthCheck")
@@ -67,6 +88,20 @@ func (r *ClusterHealthCheckReconciler) requeueClusterHealthCheckForHealthCheckRe
 	return requests
 }
 
+// getClusterInfoForHealthCheckReport returns a reference to the cluster HealthCheckReport is about
+func getClusterInfoForHealthCheckReport(healthCheckReport *libsveltosv1alpha1.HealthCheckReport) *corev1.ObjectReference {
+	kind := libsveltosv1alpha1.SveltosClusterKind
+	apiVersion := libsveltosv1alpha1.GroupVersion.String()
+
+	if healthCheckReport.Spec.ClusterType == libsveltosv1alpha1.ClusterTypeCapi {
+		kind = "Cluster"
+		apiVersion = clusterv1.GroupVersion.String()
+	}
+
+	return &corev1.ObjectReference{APIVersion: apiVersion, Kind: kind,
+		Namespace: healthCheckReport.Spec.ClusterNamespace, Name: healthCheckReport.Spec.ClusterName}
+}
+
 func (r *ClusterHealthCheckReconciler) requeueClusterHealthCheckForHealthCheck(
 	ctx context.Context, o client.Object,
 ) []reconcile.Request {
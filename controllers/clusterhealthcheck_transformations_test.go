@@ -32,6 +32,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"github.com/projectsveltos/healthcheck-manager/controllers"
+	"github.com/projectsveltos/healthcheck-manager/controllers/testhelpers"
 	libsveltosv1alpha1 "github.com/projectsveltos/libsveltos/api/v1alpha1"
 	libsveltosset "github.com/projectsveltos/libsveltos/lib/set"
 )
@@ -46,33 +47,23 @@ var _ = Describe("ClusterHealthCheckReconciler map functions", func() {
 	})
 
 	It("requeueClusterHealthCheckForCluster returns matching ClusterHealthChecks", func() {
-		cluster := &clusterv1.Cluster{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      upstreamClusterNamePrefix + randomString(),
-				Namespace: namespace,
-				Labels: map[string]string{
-					"env": "production",
-				},
-			},
-		}
-
-		matchingClusterHealthCheck := &libsveltosv1alpha1.ClusterHealthCheck{
-			ObjectMeta: metav1.ObjectMeta{
-				Name: upstreamClusterNamePrefix + randomString(),
-			},
-			Spec: libsveltosv1alpha1.ClusterHealthCheckSpec{
-				ClusterSelector: libsveltosv1alpha1.Selector("env=production"),
-			},
-		}
-
-		nonMatchingClusterHealthCheck := &libsveltosv1alpha1.ClusterHealthCheck{
-			ObjectMeta: metav1.ObjectMeta{
-				Name: upstreamClusterNamePrefix + randomString(),
-			},
-			Spec: libsveltosv1alpha1.ClusterHealthCheckSpec{
-				ClusterSelector: libsveltosv1alpha1.Selector("env=qa"),
-			},
-		}
+		cluster := testhelpers.NewCluster().
+			WithNamespace(namespace).
+			WithName(upstreamClusterNamePrefix + randomString()).
+			WithLabels(map[string]string{
+				"env": "production",
+			}).
+			Build()
+
+		matchingClusterHealthCheck := testhelpers.NewClusterHealthCheck().
+			WithName(upstreamClusterNamePrefix + randomString()).
+			WithClusterSelector("env=production").
+			Build()
+
+		nonMatchingClusterHealthCheck := testhelpers.NewClusterHealthCheck().
+			WithName(upstreamClusterNamePrefix + randomString()).
+			WithClusterSelector("env=qa").
+			Build()
 
 		initObjects := []client.Object{
 			matchingClusterHealthCheck,
@@ -160,15 +151,13 @@ var _ = Describe("ClusterHealthCheckReconciler map functions", func() {
 	})
 
 	It("RequeueClusterHealthCheckForMachine returns correct ClusterHealthChecks for a CAPI machine", func() {
-		cluster := &clusterv1.Cluster{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      upstreamClusterNamePrefix + randomString(),
-				Namespace: namespace,
-				Labels: map[string]string{
-					"env": "production",
-				},
-			},
-		}
+		cluster := testhelpers.NewCluster().
+			WithNamespace(namespace).
+			WithName(upstreamClusterNamePrefix + randomString()).
+			WithLabels(map[string]string{
+				"env": "production",
+			}).
+			Build()
 
 		cpMachine := &clusterv1.Machine{
 			ObjectMeta: metav1.ObjectMeta{
@@ -181,14 +170,10 @@ var _ = Describe("ClusterHealthCheckReconciler map functions", func() {
 			},
 		}
 
-		clusterHealthCheck := &libsveltosv1alpha1.ClusterHealthCheck{
-			ObjectMeta: metav1.ObjectMeta{
-				Name: upstreamClusterNamePrefix + randomString(),
-			},
-			Spec: libsveltosv1alpha1.ClusterHealthCheckSpec{
-				ClusterSelector: libsveltosv1alpha1.Selector("env=production"),
-			},
-		}
+		clusterHealthCheck := testhelpers.NewClusterHealthCheck().
+			WithName(upstreamClusterNamePrefix + randomString()).
+			WithClusterSelector("env=production").
+			Build()
 
 		Expect(addTypeInformationToObject(scheme, cluster)).To(Succeed())
 		Expect(addTypeInformationToObject(scheme, cpMachine)).To(Succeed())
@@ -219,4 +204,64 @@ var _ = Describe("ClusterHealthCheckReconciler map functions", func() {
 			context.TODO(), cpMachine)
 		Expect(len(clusterHealthCheckList)).To(Equal(1))
 	})
+
+	It("requeueClusterHealthCheckForHealthCheckReport returns only ClusterHealthChecks owning the report", func() {
+		healthCheckName := randomString()
+		clusterName := upstreamClusterNamePrefix + randomString()
+
+		reconciler := &controllers.ClusterHealthCheckReconciler{
+			Scheme:              scheme,
+			ClusterMap:          make(map[corev1.ObjectReference]*libsveltosset.Set),
+			CHCToClusterMap:     make(map[types.NamespacedName]*libsveltosset.Set),
+			ClusterHealthChecks: make(map[corev1.ObjectReference]libsveltosv1alpha1.Selector),
+			HealthCheckMap:      make(map[corev1.ObjectReference]*libsveltosset.Set),
+			CHCToHealthCheckMap: make(map[types.NamespacedName]*libsveltosset.Set),
+			ClusterLabels:       make(map[corev1.ObjectReference]map[string]string),
+			Mux:                 sync.Mutex{},
+		}
+
+		owner := corev1.ObjectReference{APIVersion: libsveltosv1alpha1.GroupVersion.String(),
+			Kind: libsveltosv1alpha1.ClusterHealthCheckKind, Name: upstreamClusterNamePrefix + randomString()}
+		other := corev1.ObjectReference{APIVersion: libsveltosv1alpha1.GroupVersion.String(),
+			Kind: libsveltosv1alpha1.ClusterHealthCheckKind, Name: upstreamClusterNamePrefix + randomString()}
+
+		// Both ClusterHealthChecks reference the HealthCheck
+		healthCheckInfo := corev1.ObjectReference{APIVersion: libsveltosv1alpha1.GroupVersion.String(),
+			Kind: libsveltosv1alpha1.HealthCheckKind, Name: healthCheckName}
+		consumers := &libsveltosset.Set{}
+		consumers.Insert(&owner)
+		consumers.Insert(&other)
+		reconciler.HealthCheckMap[healthCheckInfo] = consumers
+
+		// Only owner matches the cluster
+		clusterInfo := corev1.ObjectReference{APIVersion: libsveltosv1alpha1.GroupVersion.String(),
+			Kind: libsveltosv1alpha1.SveltosClusterKind, Namespace: namespace, Name: clusterName}
+		matching := &libsveltosset.Set{}
+		matching.Insert(&owner)
+		reconciler.ClusterMap[clusterInfo] = matching
+
+		healthCheckReport := &libsveltosv1alpha1.HealthCheckReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      randomString(),
+			},
+			Spec: libsveltosv1alpha1.HealthCheckReportSpec{
+				ClusterNamespace: namespace,
+				ClusterName:      clusterName,
+				ClusterType:      libsveltosv1alpha1.ClusterTypeSveltos,
+				HealthCheckName:  healthCheckName,
+			},
+		}
+
+		requests := controllers.RequeueClusterHealthCheckForHealthCheckReport(reconciler, context.TODO(), healthCheckReport)
+		Expect(requests).To(HaveLen(1))
+		Expect(requests).To(ContainElement(reconcile.Request{NamespacedName: types.NamespacedName{Name: owner.Name}}))
+
+		By("Falling back to all ClusterHealthChecks referencing the HealthCheck when cluster is unknown")
+		healthCheckReport.Spec.ClusterName = randomString()
+		requests = controllers.RequeueClusterHealthCheckForHealthCheckReport(reconciler, context.TODO(), healthCheckReport)
+		Expect(requests).To(HaveLen(2))
+		Expect(requests).To(ContainElement(reconcile.Request{NamespacedName: types.NamespacedName{Name: owner.Name}}))
+		Expect(requests).To(ContainElement(reconcile.Request{NamespacedName: types.NamespacedName{Name: other.Name}}))
+	})
 })
@@ -0,0 +1,161 @@
+/*
+Copyright 2024. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	libsveltosv1alpha1 "github.com/projectsveltos/libsveltos/api/v1alpha1"
+)
+
+const (
+	errorTypeClusterNotFound    = "cluster_not_found"
+	errorTypeEvaluationTimeout  = "evaluation_timeout"
+	errorTypePermissionDenied   = "permission_denied"
+	errorTypeNetworkFailure     = "network_failure"
+	errorTypeUncategorizedError = "unknown"
+)
+
+// ClusterNotFoundError is returned when a managed cluster cannot be found
+type ClusterNotFoundError struct {
+	Cluster string
+	Err     error
+}
+
+func (e *ClusterNotFoundError) Error() string {
+	return fmt.Sprintf("cluster %s not found: %v", e.Cluster, e.Err)
+}
+
+func (e *ClusterNotFoundError) Unwrap() error {
+	return e.Err
+}
+
+// EvaluationTimeoutError is returned when evaluating health did not complete in time
+type EvaluationTimeoutError struct {
+	Err error
+}
+
+func (e *EvaluationTimeoutError) Error() string {
+	return fmt.Sprintf("evaluation timed out: %v", e.Err)
+}
+
+func (e *EvaluationTimeoutError) Unwrap() error {
+	return e.Err
+}
+
+// PermissionDeniedError is returned when access to a resource is not authorized
+type PermissionDeniedError struct {
+	Err error
+}
+
+func (e *PermissionDeniedError) Error() string {
+	return fmt.Sprintf("permission denied: %v", e.Err)
+}
+
+func (e *PermissionDeniedError) Unwrap() error {
+	return e.Err
+}
+
+// NetworkFailureError is returned when an API server cannot be reached
+type NetworkFailureError struct {
+	Err error
+}
+
+func (e *NetworkFailureError) Error() string {
+	return fmt.Sprintf("network failure: %v", e.Err)
+}
+
+func (e *NetworkFailureError) Unwrap() error {
+	return e.Err
+}
+
+// wrapClusterError returns a ClusterNotFoundError if err reports cluster does not exist.
+// Any other error is wrapped by wrapRemoteError.
+func wrapClusterError(err error, clusterNamespace, clusterName string,
+	clusterType libsveltosv1alpha1.ClusterType) error {
+
+	if !apierrors.IsNotFound(err) {
+		return wrapRemoteError(err)
+	}
+
+	return &ClusterNotFoundError{
+		Cluster: fmt.Sprintf("%s:%s/%s", clusterType, clusterNamespace, clusterName),
+		Err:     err,
+	}
+}
+
+// wrapRemoteError returns an EvaluationTimeoutError, a PermissionDeniedError or a NetworkFailureError
+// if err reports an API server did not answer in time, denied access or could not be reached.
+// Any other error, including an already typed one, is returned unchanged.
+func wrapRemoteError(err error) error {
+	if err == nil || isTypedError(err) {
+		return err
+	}
+
+	var netErr net.Error
+	switch {
+	case errors.Is(err, context.DeadlineExceeded), apierrors.IsTimeout(err), apierrors.IsServerTimeout(err):
+		return &EvaluationTimeoutError{Err: err}
+	case apierrors.IsForbidden(err), apierrors.IsUnauthorized(err):
+		return &PermissionDeniedError{Err: err}
+	case errors.As(err, &netErr):
+		return &NetworkFailureError{Err: err}
+	default:
+		return err
+	}
+}
+
+// isTypedError returns true if err is, or wraps, one of the typed errors defined in this file
+func isTypedError(err error) bool {
+	var clusterNotFoundErr *ClusterNotFoundError
+	var evaluationTimeoutErr *EvaluationTimeoutError
+	var permissionDeniedErr *PermissionDeniedError
+	var networkFailureErr *NetworkFailureError
+
+	return errors.As(err, &clusterNotFoundErr) || errors.As(err, &evaluationTimeoutErr) ||
+		errors.As(err, &permissionDeniedErr) || errors.As(err, &networkFailureErr)
+}
+
+// getErrorType returns the category err belongs to.
+// Typed errors are considered first. Well known errors which were not wrapped where
+// they occurred are mapped to a category the same way wrapRemoteError does.
+func getErrorType(err error) string {
+	var clusterNotFoundErr *ClusterNotFoundError
+	var evaluationTimeoutErr *EvaluationTimeoutError
+	var permissionDeniedErr *PermissionDeniedError
+	var networkFailureErr *NetworkFailureError
+
+	err = wrapRemoteError(err)
+
+	switch {
+	case errors.As(err, &clusterNotFoundErr):
+		return errorTypeClusterNotFound
+	case errors.As(err, &evaluationTimeoutErr):
+		return errorTypeEvaluationTimeout
+	case errors.As(err, &permissionDeniedErr):
+		return errorTypePermissionDenied
+	case errors.As(err, &networkFailureErr):
+		return errorTypeNetworkFailure
+	default:
+		return errorTypeUncategorizedError
+	}
+}
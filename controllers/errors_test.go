@@ -0,0 +1,172 @@
+/*
+Copyright 2024. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/klog/v2/textlogger"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	"github.com/projectsveltos/healthcheck-manager/controllers"
+	"github.com/projectsveltos/healthcheck-manager/controllers/testhelpers"
+	libsveltosv1alpha1 "github.com/projectsveltos/libsveltos/api/v1alpha1"
+)
+
+var _ = Describe("Errors", func() {
+	It("getErrorType categorizes typed errors, also when wrapped", func() {
+		rootCause := errors.New(randomString())
+
+		Expect(controllers.GetErrorType(
+			fmt.Errorf("wrapped: %w", &controllers.ClusterNotFoundError{Cluster: randomString(), Err: rootCause}))).
+			To(Equal("cluster_not_found"))
+		Expect(controllers.GetErrorType(
+			fmt.Errorf("wrapped: %w", &controllers.EvaluationTimeoutError{Err: rootCause}))).
+			To(Equal("evaluation_timeout"))
+		Expect(controllers.GetErrorType(
+			fmt.Errorf("wrapped: %w", &controllers.PermissionDeniedError{Err: rootCause}))).
+			To(Equal("permission_denied"))
+		Expect(controllers.GetErrorType(
+			fmt.Errorf("wrapped: %w", &controllers.NetworkFailureError{Err: rootCause}))).
+			To(Equal("network_failure"))
+		Expect(controllers.GetErrorType(rootCause)).To(Equal("unknown"))
+
+		var clusterNotFoundErr *controllers.ClusterNotFoundError
+		err := fmt.Errorf("wrapped: %w", &controllers.ClusterNotFoundError{Cluster: randomString(), Err: rootCause})
+		Expect(errors.As(err, &clusterNotFoundErr)).To(BeTrue())
+		Expect(errors.Is(err, rootCause)).To(BeTrue())
+	})
+
+	It("getErrorType categorizes well known errors", func() {
+		Expect(controllers.GetErrorType(fmt.Errorf("wrapped: %w", context.DeadlineExceeded))).
+			To(Equal("evaluation_timeout"))
+		Expect(controllers.GetErrorType(apierrors.NewTimeoutError(randomString(), 1))).
+			To(Equal("evaluation_timeout"))
+		Expect(controllers.GetErrorType(apierrors.NewForbidden(schema.GroupResource{Resource: "secrets"},
+			randomString(), errors.New(randomString())))).To(Equal("permission_denied"))
+		Expect(controllers.GetErrorType(apierrors.NewUnauthorized(randomString()))).
+			To(Equal("permission_denied"))
+		Expect(controllers.GetErrorType(&net.OpError{Op: "dial", Err: errors.New(randomString())})).
+			To(Equal("network_failure"))
+	})
+
+	It("wrapRemoteError wraps well known errors into typed errors", func() {
+		timeoutErr := fmt.Errorf("wrapped: %w", context.DeadlineExceeded)
+		var evaluationTimeoutErr *controllers.EvaluationTimeoutError
+		err := controllers.WrapRemoteError(timeoutErr)
+		Expect(errors.As(err, &evaluationTimeoutErr)).To(BeTrue())
+		Expect(errors.Is(err, context.DeadlineExceeded)).To(BeTrue())
+
+		forbiddenErr := apierrors.NewForbidden(schema.GroupResource{Resource: "secrets"},
+			randomString(), errors.New(randomString()))
+		var permissionDeniedErr *controllers.PermissionDeniedError
+		Expect(errors.As(controllers.WrapRemoteError(forbiddenErr), &permissionDeniedErr)).To(BeTrue())
+
+		netErr := &net.OpError{Op: "dial", Err: errors.New(randomString())}
+		var networkFailureErr *controllers.NetworkFailureError
+		Expect(errors.As(controllers.WrapRemoteError(netErr), &networkFailureErr)).To(BeTrue())
+
+		// Not found and uncategorized errors are returned unchanged
+		notFoundErr := apierrors.NewNotFound(schema.GroupResource{Resource: "secrets"}, randomString())
+		Expect(controllers.WrapRemoteError(notFoundErr)).To(Equal(notFoundErr))
+		rootCause := errors.New(randomString())
+		Expect(controllers.WrapRemoteError(rootCause)).To(Equal(rootCause))
+
+		// Typed errors are not wrapped twice
+		typedErr := &controllers.EvaluationTimeoutError{Err: context.DeadlineExceeded}
+		Expect(controllers.WrapRemoteError(typedErr)).To(Equal(typedErr))
+		Expect(controllers.WrapRemoteError(nil)).To(BeNil())
+	})
+
+	It("wrapClusterError wraps not found errors into ClusterNotFoundError", func() {
+		clusterNamespace := randomString()
+		clusterName := randomString()
+
+		notFoundErr := apierrors.NewNotFound(schema.GroupResource{Resource: "sveltosclusters"}, clusterName)
+		var clusterNotFoundErr *controllers.ClusterNotFoundError
+		Expect(errors.As(controllers.WrapClusterError(notFoundErr, clusterNamespace, clusterName,
+			libsveltosv1alpha1.ClusterTypeSveltos), &clusterNotFoundErr)).To(BeTrue())
+		Expect(clusterNotFoundErr.Cluster).To(Equal(fmt.Sprintf("%s:%s/%s", libsveltosv1alpha1.ClusterTypeSveltos,
+			clusterNamespace, clusterName)))
+
+		var permissionDeniedErr *controllers.PermissionDeniedError
+		Expect(errors.As(controllers.WrapClusterError(apierrors.NewUnauthorized(randomString()), clusterNamespace,
+			clusterName, libsveltosv1alpha1.ClusterTypeSveltos), &permissionDeniedErr)).To(BeTrue())
+	})
+
+	It("removeStaleHealthChecks returns PermissionDeniedError when access to managed cluster is denied", func() {
+		clusterNamespace := randomString()
+		clusterName := randomString()
+
+		chc := testhelpers.NewClusterHealthCheck().WithName(randomString()).Build()
+
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(chc).
+			WithInterceptorFuncs(interceptor.Funcs{
+				Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object,
+					opts ...client.GetOption) error {
+
+					return apierrors.NewForbidden(schema.GroupResource{Resource: "secrets"}, key.Name,
+						errors.New(randomString()))
+				},
+			}).Build()
+
+		logger := textlogger.NewLogger(textlogger.NewConfig(textlogger.Verbosity(1)))
+		err := controllers.RemoveStaleHealthChecks(context.TODO(), c, clusterNamespace, clusterName,
+			libsveltosv1alpha1.ClusterTypeSveltos, chc, logger)
+		Expect(err).To(HaveOccurred())
+		var permissionDeniedErr *controllers.PermissionDeniedError
+		Expect(errors.As(err, &permissionDeniedErr)).To(BeTrue())
+		Expect(controllers.GetErrorType(err)).To(Equal("permission_denied"))
+	})
+
+	It("trackReconcileError increments counter for error type", func() {
+		errs := map[string]error{
+			"cluster_not_found":  &controllers.ClusterNotFoundError{Cluster: randomString()},
+			"evaluation_timeout": &controllers.EvaluationTimeoutError{},
+			"permission_denied":  &controllers.PermissionDeniedError{},
+			"network_failure":    &controllers.NetworkFailureError{},
+			"unknown":            errors.New(randomString()),
+		}
+
+		for errorType, err := range errs {
+			before := make(map[string]float64)
+			for t := range errs {
+				before[t] = controllers.GetReconcileErrors(t)
+			}
+
+			controllers.TrackReconcileError(fmt.Errorf("wrapped: %w", err))
+
+			for t := range errs {
+				if t == errorType {
+					Expect(controllers.GetReconcileErrors(t)).To(Equal(before[t] + 1))
+				} else {
+					Expect(controllers.GetReconcileErrors(t)).To(Equal(before[t]))
+				}
+			}
+		}
+	})
+})
@@ -0,0 +1,134 @@
+/*
+Copyright 2024. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	libsveltosv1alpha1 "github.com/projectsveltos/libsveltos/api/v1alpha1"
+)
+
+const (
+	// evaluationDurationSamples is the number of most recent evaluation durations
+	// used to compute the rolling average
+	evaluationDurationSamples = 10
+)
+
+var (
+	evaluationDurationsMux sync.Mutex
+	// evaluationDurations contains, per ClusterHealthCheck/cluster pair, the most recent evaluation durations
+	evaluationDurations = map[evaluationDurationKey]*durationRingBuffer{}
+)
+
+type evaluationDurationKey struct {
+	chcName string
+	cluster string
+}
+
+// durationRingBuffer keeps the last N recorded values (in milliseconds).
+// Once full, each new value overwrites the oldest one.
+type durationRingBuffer struct {
+	values []float64
+	next   int
+	count  int
+}
+
+func newDurationRingBuffer(size int) *durationRingBuffer {
+	return &durationRingBuffer{
+		values: make([]float64, size),
+	}
+}
+
+// add records value, overwriting the oldest one when buffer is full
+func (b *durationRingBuffer) add(value float64) {
+	b.values[b.next] = value
+	b.next = (b.next + 1) % len(b.values)
+	if b.count < len(b.values) {
+		b.count++
+	}
+}
+
+// average returns the average of the values currently in the buffer.
+// Returns 0 if no value has been recorded yet.
+func (b *durationRingBuffer) average() float64 {
+	if b.count == 0 {
+		return 0
+	}
+
+	sum := float64(0)
+	for i := 0; i < b.count; i++ {
+		sum += b.values[i]
+	}
+	return sum / float64(b.count)
+}
+
+// recordEvaluationDuration records how long evaluating a ClusterHealthCheck in a cluster took and
+// returns the rolling average (in milliseconds) over the last evaluationDurationSamples evaluations.
+// Rolling average is also exposed as a metric.
+func recordEvaluationDuration(chcName, clusterNamespace, clusterName string, clusterType libsveltosv1alpha1.ClusterType,
+	elapsed time.Duration) float64 {
+
+	ms := float64(elapsed) / float64(time.Millisecond)
+	evaluationDurationHistogram.Observe(ms)
+
+	clusterInfo := fmt.Sprintf("%s:%s/%s", clusterType, clusterNamespace, clusterName)
+	key := evaluationDurationKey{chcName: chcName, cluster: clusterInfo}
+
+	evaluationDurationsMux.Lock()
+	defer evaluationDurationsMux.Unlock()
+
+	buffer, ok := evaluationDurations[key]
+	if !ok {
+		buffer = newDurationRingBuffer(evaluationDurationSamples)
+		evaluationDurations[key] = buffer
+	}
+	buffer.add(ms)
+
+	average := buffer.average()
+	evaluationDurationAverageGauge.WithLabelValues(clusterInfo, chcName).Set(average)
+	return average
+}
+
+// removeEvaluationDuration forgets evaluation durations recorded for a ClusterHealthCheck/cluster pair.
+// Invoked when ClusterHealthCheck is undeployed from cluster.
+func removeEvaluationDuration(chcName, clusterNamespace, clusterName string, clusterType libsveltosv1alpha1.ClusterType) {
+	clusterInfo := fmt.Sprintf("%s:%s/%s", clusterType, clusterNamespace, clusterName)
+
+	evaluationDurationsMux.Lock()
+	defer evaluationDurationsMux.Unlock()
+
+	delete(evaluationDurations, evaluationDurationKey{chcName: chcName, cluster: clusterInfo})
+	evaluationDurationAverageGauge.DeleteLabelValues(clusterInfo, chcName)
+}
+
+// resetEvaluationDurations forgets, for all clusters, evaluation durations recorded for a ClusterHealthCheck.
+// Invoked when ClusterHealthCheck is deleted.
+func resetEvaluationDurations(chcName string) {
+	evaluationDurationsMux.Lock()
+	defer evaluationDurationsMux.Unlock()
+
+	for key := range evaluationDurations {
+		if key.chcName == chcName {
+			delete(evaluationDurations, key)
+		}
+	}
+	evaluationDurationAverageGauge.DeletePartialMatch(prometheus.Labels{"check_name": chcName})
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2024. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/projectsveltos/healthcheck-manager/controllers"
+	libsveltosv1alpha1 "github.com/projectsveltos/libsveltos/api/v1alpha1"
+)
+
+var _ = Describe("Evaluation duration", func() {
+	var chcName string
+	var clusterNamespace string
+	var clusterName string
+	clusterType := libsveltosv1alpha1.ClusterTypeCapi
+
+	BeforeEach(func() {
+		chcName = randomString()
+		clusterNamespace = randomString()
+		clusterName = randomString()
+	})
+
+	AfterEach(func() {
+		controllers.ResetEvaluationDurations(chcName)
+	})
+
+	It("recordEvaluationDuration returns the average of recorded durations", func() {
+		Expect(controllers.RecordEvaluationDuration(chcName, clusterNamespace, clusterName, clusterType, 10*time.Millisecond)).To(Equal(float64(10)))
+		Expect(controllers.RecordEvaluationDuration(chcName, clusterNamespace, clusterName, clusterType, 20*time.Millisecond)).To(Equal(float64(15)))
+		Expect(controllers.RecordEvaluationDuration(chcName, clusterNamespace, clusterName, clusterType, 60*time.Millisecond)).To(Equal(float64(30)))
+	})
+
+	It("recordEvaluationDuration only considers last 10 durations", func() {
+		var average float64
+		for i := 1; i <= 12; i++ {
+			average = controllers.RecordEvaluationDuration(chcName, clusterNamespace, clusterName, clusterType, time.Duration(i)*time.Millisecond)
+		}
+		// durations 1ms and 2ms have been overwritten. Average of 3..12
+		Expect(average).To(Equal(float64(7.5)))
+
+		for i := 0; i < 10; i++ {
+			average = controllers.RecordEvaluationDuration(chcName, clusterNamespace, clusterName, clusterType, 100*time.Millisecond)
+		}
+		Expect(average).To(Equal(float64(100)))
+	})
+
+	It("recordEvaluationDuration keeps separate averages per ClusterHealthCheck", func() {
+		otherChcName := randomString()
+		defer controllers.ResetEvaluationDurations(otherChcName)
+
+		Expect(controllers.RecordEvaluationDuration(chcName, clusterNamespace, clusterName, clusterType, 10*time.Millisecond)).To(Equal(float64(10)))
+		Expect(controllers.RecordEvaluationDuration(otherChcName, clusterNamespace, clusterName, clusterType, 30*time.Millisecond)).To(Equal(float64(30)))
+		Expect(controllers.RecordEvaluationDuration(chcName, clusterNamespace, clusterName, clusterType, 20*time.Millisecond)).To(Equal(float64(15)))
+	})
+
+	It("recordEvaluationDuration keeps separate averages per cluster", func() {
+		otherClusterName := randomString()
+
+		Expect(controllers.RecordEvaluationDuration(chcName, clusterNamespace, clusterName, clusterType,
+			10*time.Millisecond)).To(Equal(float64(10)))
+		Expect(controllers.RecordEvaluationDuration(chcName, clusterNamespace, otherClusterName, clusterType,
+			30*time.Millisecond)).To(Equal(float64(30)))
+		Expect(controllers.RecordEvaluationDuration(chcName, clusterNamespace, clusterName, clusterType,
+			20*time.Millisecond)).To(Equal(float64(15)))
+	})
+
+	It("recordEvaluationDuration exports rolling average per cluster", func() {
+		otherClusterName := randomString()
+
+		controllers.RecordEvaluationDuration(chcName, clusterNamespace, clusterName, clusterType, 10*time.Millisecond)
+		controllers.RecordEvaluationDuration(chcName, clusterNamespace, clusterName, clusterType, 20*time.Millisecond)
+		controllers.RecordEvaluationDuration(chcName, clusterNamespace, otherClusterName, clusterType, 40*time.Millisecond)
+
+		Expect(controllers.GetEvaluationDurationAverage(clusterNamespace, clusterName, clusterType, chcName)).
+			To(Equal(float64(15)))
+		Expect(controllers.GetEvaluationDurationAverage(clusterNamespace, otherClusterName, clusterType, chcName)).
+			To(Equal(float64(40)))
+	})
+
+	It("removeEvaluationDuration and resetEvaluationDurations remove exported averages", func() {
+		otherClusterName := randomString()
+
+		initialCount := controllers.CountEvaluationDurationAverages()
+		controllers.RecordEvaluationDuration(chcName, clusterNamespace, clusterName, clusterType, 10*time.Millisecond)
+		controllers.RecordEvaluationDuration(chcName, clusterNamespace, otherClusterName, clusterType, 40*time.Millisecond)
+		Expect(controllers.CountEvaluationDurationAverages()).To(Equal(initialCount + 2))
+
+		controllers.RemoveEvaluationDuration(chcName, clusterNamespace, clusterName, clusterType)
+		Expect(controllers.CountEvaluationDurationAverages()).To(Equal(initialCount + 1))
+		// Only the removed cluster starts from scratch
+		Expect(controllers.RecordEvaluationDuration(chcName, clusterNamespace, otherClusterName, clusterType,
+			20*time.Millisecond)).To(Equal(float64(30)))
+		controllers.RemoveEvaluationDuration(chcName, clusterNamespace, otherClusterName, clusterType)
+
+		controllers.RecordEvaluationDuration(chcName, clusterNamespace, clusterName, clusterType, 10*time.Millisecond)
+		controllers.RecordEvaluationDuration(chcName, clusterNamespace, otherClusterName, clusterType, 40*time.Millisecond)
+		controllers.ResetEvaluationDurations(chcName)
+		Expect(controllers.CountEvaluationDurationAverages()).To(Equal(initialCount))
+	})
+
+	It("resetEvaluationDurations forgets recorded durations", func() {
+		Expect(controllers.RecordEvaluationDuration(chcName, clusterNamespace, clusterName, clusterType, 10*time.Millisecond)).To(Equal(float64(10)))
+		controllers.ResetEvaluationDurations(chcName)
+		Expect(controllers.RecordEvaluationDuration(chcName, clusterNamespace, clusterName, clusterType, 40*time.Millisecond)).To(Equal(float64(40)))
+	})
+})
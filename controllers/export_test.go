@@ -16,10 +16,29 @@ limitations under the License.
 
 package controllers
 
+import (
+	"fmt"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	libsveltosv1alpha1 "github.com/projectsveltos/libsveltos/api/v1alpha1"
+)
+
 var (
 	RequeueClusterHealthCheckForCluster = (*ClusterHealthCheckReconciler).requeueClusterHealthCheckForCluster
 	RequeueClusterHealthCheckForMachine = (*ClusterHealthCheckReconciler).requeueClusterHealthCheckForMachine
 
+	RequeueClusterHealthCheckForHealthCheckReport = (*ClusterHealthCheckReconciler).requeueClusterHealthCheckForHealthCheckReport
+
+	GetEventFilterPredicate        = getEventFilterPredicate[client.Object]
+	GetClusterEventFilterPredicate = getEventFilterPredicate[*clusterv1.Cluster]
+	GetClusterPredicate            = (*ClusterHealthCheckReconciler).getClusterPredicate
+	GetChangedSpecFields           = getChangedSpecFields
+
 	CleanMaps               = (*ClusterHealthCheckReconciler).cleanMaps
 	UpdateMaps              = (*ClusterHealthCheckReconciler).updateMaps
 	GetReferenceMapForEntry = (*ClusterHealthCheckReconciler).getReferenceMapForEntry
@@ -54,6 +73,8 @@ var (
 	HasLivenessCheckStatusChange = hasLivenessCheckStatusChange
 	EvaluateLivenessCheckAddOns  = evaluateLivenessCheckAddOns
 	EvaluateLivenessCheck        = evaluateLivenessCheck
+	ResetResourcesEvaluated      = resetResourcesEvaluated
+	SetResourcesEvaluated        = setResourcesEvaluated
 
 	DoSendNotification         = doSendNotification
 	BuildNotificationStatusMap = buildNotificationStatusMap
@@ -66,6 +87,9 @@ var (
 	DeployHealthChecks                    = deployHealthChecks
 	RemoveStaleHealthChecks               = removeStaleHealthChecks
 	GetReferencedHealthChecks             = getReferencedHealthChecks
+	ProcessClusterHealthCheckForCluster   = processClusterHealthCheckForCluster
+	IsClusterOptedOut                     = isClusterOptedOut
+
 )
 
 var (
@@ -79,8 +103,10 @@ var (
 )
 
 var (
-	GetSlackInfo = getSlackInfo
-	GetWebexInfo = getWebexInfo
+	GetSlackInfo          = getSlackInfo
+	GetSlackBlocks        = getSlackBlocks
+	SendSlackNotification = sendSlackNotification
+	GetWebexInfo          = getWebexInfo
 )
 
 func GetWebexRoom(info *webexInfo) string {
@@ -96,3 +122,105 @@ func GetSlackChannelID(info *slackInfo) string {
 func GetSlackToken(info *slackInfo) string {
 	return info.token
 }
+
+// SetSlackAPIURL sets the Slack API endpoint and returns the previous one
+func SetSlackAPIURL(url string) string {
+	old := slackAPIURL
+	slackAPIURL = url
+	return old
+}
+
+// GetResourcesEvaluated returns number of resources reported as evaluated by HealthCheck in a cluster
+func GetResourcesEvaluated(clusterNamespace, clusterName string, clusterType libsveltosv1alpha1.ClusterType,
+	healthCheckName string) float64 {
+
+	clusterInfo := fmt.Sprintf("%s:%s/%s", clusterType, clusterNamespace, clusterName)
+	return testutil.ToFloat64(resourcesEvaluatedGauge.WithLabelValues(clusterInfo, healthCheckName))
+}
+
+// CountResourcesEvaluated returns number of series currently recorded by resources evaluated metric
+func CountResourcesEvaluated() int {
+	return testutil.CollectAndCount(resourcesEvaluatedGauge)
+}
+
+var (
+	PublishEvaluation       = publishEvaluation
+	PublishHealthTransition = publishHealthTransition
+
+	StartReconcileSpan = startReconcileSpan
+)
+
+// GetPublishFailures returns number of evaluation results which failed to be published
+func GetPublishFailures() float64 {
+	return testutil.ToFloat64(publishFailures)
+}
+
+// GetReconcilePanics returns number of panics recorded for operation ("reconciliation" or "evaluation")
+func GetReconcilePanics(operation string) float64 {
+	return testutil.ToFloat64(reconcilePanics.WithLabelValues(operation))
+}
+
+var (
+	GetErrorType        = getErrorType
+	TrackReconcileError = trackReconcileError
+	WrapClusterError    = wrapClusterError
+	WrapRemoteError     = wrapRemoteError
+)
+
+// GetReconcileErrors returns number of reconciliation errors recorded for errorType
+func GetReconcileErrors(errorType string) float64 {
+	return testutil.ToFloat64(reconcileErrors.WithLabelValues(errorType))
+}
+
+// SetInvalidationsChannel sets the channel used to requeue ClusterHealthChecks on cache invalidation
+func SetInvalidationsChannel(r *ClusterHealthCheckReconciler, ch chan event.GenericEvent) {
+	r.invalidations = ch
+}
+
+var (
+	RecordEvaluationDuration = recordEvaluationDuration
+	ResetEvaluationDurations = resetEvaluationDurations
+	RemoveEvaluationDuration = removeEvaluationDuration
+)
+
+var (
+	GetAuditEvent   = getAuditEvent
+	AuditEvaluation = auditEvaluation
+)
+
+var (
+	RecordClusterHealthStatus = recordClusterHealthStatus
+	RemoveClusterHealthStatus = removeClusterHealthStatus
+	GetHealthStatus           = getHealthStatus
+	GetFailingConditions      = getFailingConditions
+)
+
+// GetClusterHealthStatus returns evaluation result currently stored for a ClusterHealthCheck/cluster pair
+func GetClusterHealthStatus(chcName, clusterNamespace, clusterName string,
+	clusterType libsveltosv1alpha1.ClusterType) *ClusterHealthStatus {
+
+	clusterHealthStatusesMux.RLock()
+	defer clusterHealthStatusesMux.RUnlock()
+
+	return clusterHealthStatuses[getClusterHealthStatusKey(chcName, clusterNamespace, clusterName, clusterType)]
+}
+
+// GetEvaluationDurationAverage returns rolling average evaluation duration reported for a ClusterHealthCheck in a cluster
+func GetEvaluationDurationAverage(clusterNamespace, clusterName string, clusterType libsveltosv1alpha1.ClusterType,
+	chcName string) float64 {
+
+	clusterInfo := fmt.Sprintf("%s:%s/%s", clusterType, clusterNamespace, clusterName)
+	return testutil.ToFloat64(evaluationDurationAverageGauge.WithLabelValues(clusterInfo, chcName))
+}
+
+// CountEvaluationDurationAverages returns number of series currently recorded by evaluation duration average metric
+func CountEvaluationDurationAverages() int {
+	return testutil.CollectAndCount(evaluationDurationAverageGauge)
+}
+
+// SetPublishTimeout sets how long publishing an evaluation event can take. Returns previous value.
+func SetPublishTimeout(timeout time.Duration) time.Duration {
+	old := publishTimeout
+	publishTimeout = timeout
+	return old
+}
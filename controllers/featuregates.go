@@ -0,0 +1,87 @@
+/*
+Copyright 2024. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// FeatureGate is the name of an experimental feature
+type FeatureGate string
+
+// Gates are reserved for experimental features in development. No code path is guarded by
+// them yet, so enabling one does not change behavior. Each feature will check its gate with
+// FeatureGateEnabled once it lands.
+const (
+	// CELExpressions enables evaluating health using CEL expressions
+	CELExpressions = FeatureGate("CELExpressions")
+
+	// StreamingAPI enables streaming evaluation results
+	StreamingAPI = FeatureGate("StreamingAPI")
+
+	// AutoRemediation enables automated remediation of unhealthy resources
+	AutoRemediation = FeatureGate("AutoRemediation")
+)
+
+var (
+	// featureGates contains all known feature gates. All gates are disabled by default.
+	featureGates = map[FeatureGate]bool{
+		CELExpressions:  false,
+		StreamingAPI:    false,
+		AutoRemediation: false,
+	}
+)
+
+// SetFeatureGates enables the feature gates listed in value (comma separated gate names).
+// Any gate not listed is disabled. Returns an error if an unknown gate is listed.
+func SetFeatureGates(value string) error {
+	enabled := make(map[FeatureGate]bool)
+	for _, name := range strings.Split(value, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		gate := FeatureGate(name)
+		if _, ok := featureGates[gate]; !ok {
+			return fmt.Errorf("unknown feature gate %q. Known feature gates: %s",
+				name, strings.Join(knownFeatureGates(), ","))
+		}
+		enabled[gate] = true
+	}
+
+	for gate := range featureGates {
+		featureGates[gate] = enabled[gate]
+	}
+
+	return nil
+}
+
+// FeatureGateEnabled returns true if gate is enabled
+func FeatureGateEnabled(gate FeatureGate) bool {
+	return featureGates[gate]
+}
+
+func knownFeatureGates() []string {
+	names := make([]string, 0, len(featureGates))
+	for gate := range featureGates {
+		names = append(names, string(gate))
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2024. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/projectsveltos/healthcheck-manager/controllers"
+)
+
+var _ = Describe("FeatureGates", func() {
+	AfterEach(func() {
+		Expect(controllers.SetFeatureGates("")).To(Succeed())
+	})
+
+	It("all feature gates are disabled by default", func() {
+		Expect(controllers.FeatureGateEnabled(controllers.CELExpressions)).To(BeFalse())
+		Expect(controllers.FeatureGateEnabled(controllers.StreamingAPI)).To(BeFalse())
+		Expect(controllers.FeatureGateEnabled(controllers.AutoRemediation)).To(BeFalse())
+	})
+
+	It("SetFeatureGates enables only listed feature gates", func() {
+		Expect(controllers.SetFeatureGates("CELExpressions, AutoRemediation")).To(Succeed())
+		Expect(controllers.FeatureGateEnabled(controllers.CELExpressions)).To(BeTrue())
+		Expect(controllers.FeatureGateEnabled(controllers.AutoRemediation)).To(BeTrue())
+		Expect(controllers.FeatureGateEnabled(controllers.StreamingAPI)).To(BeFalse())
+
+		Expect(controllers.SetFeatureGates("StreamingAPI")).To(Succeed())
+		Expect(controllers.FeatureGateEnabled(controllers.CELExpressions)).To(BeFalse())
+		Expect(controllers.FeatureGateEnabled(controllers.AutoRemediation)).To(BeFalse())
+		Expect(controllers.FeatureGateEnabled(controllers.StreamingAPI)).To(BeTrue())
+	})
+
+	It("SetFeatureGates returns an error for unknown feature gates", func() {
+		Expect(controllers.SetFeatureGates("CELExpressions")).To(Succeed())
+
+		err := controllers.SetFeatureGates("CELExpressions," + randomString())
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(ContainSubstring("unknown feature gate"))
+
+		// previously set feature gates are left untouched
+		Expect(controllers.FeatureGateEnabled(controllers.CELExpressions)).To(BeTrue())
+	})
+
+	It("FeatureGateEnabled returns false for unknown feature gates", func() {
+		Expect(controllers.FeatureGateEnabled(controllers.FeatureGate(randomString()))).To(BeFalse())
+	})
+})
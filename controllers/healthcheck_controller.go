@@ -53,6 +53,7 @@ func (r *HealthCheckReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 			if err != nil {
 				return reconcile.Result{}, err
 			}
+			resetResourcesEvaluated(req.Name)
 			return reconcile.Result{}, nil
 		}
 		logger.Error(err, "Failed to fetch healthCheck")
@@ -69,6 +70,7 @@ func (r *HealthCheckReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		if err != nil {
 			return reconcile.Result{}, err
 		}
+		resetResourcesEvaluated(healthCheck.Name)
 		return reconcile.Result{}, nil
 	}
 
@@ -0,0 +1,166 @@
+/*
+Copyright 2024. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	libsveltosv1alpha1 "github.com/projectsveltos/libsveltos/api/v1alpha1"
+)
+
+const (
+	// ClusterHealthStatusesPath is the path, on the diagnostics (metrics) server, live health status
+	// is served at. Callers need to be granted get on this nonResourceURL.
+	ClusterHealthStatusesPath = "/clusterhealthstatuses"
+
+	clusterHealthStatusListKind = "ClusterHealthStatusList"
+
+	// Health status of a cluster: healthy when all liveness checks are passing, degraded otherwise
+	HealthStatusHealthy  = "Healthy"
+	HealthStatusDegraded = "Degraded"
+)
+
+// ClusterHealthStatus is the last evaluation result of a ClusterHealthCheck for a cluster
+type ClusterHealthStatus struct {
+	ClusterHealthCheckName string                         `json:"clusterHealthCheckName"`
+	ClusterNamespace       string                         `json:"clusterNamespace"`
+	ClusterName            string                         `json:"clusterName"`
+	ClusterType            libsveltosv1alpha1.ClusterType `json:"clusterType"`
+	Healthy                bool                           `json:"healthy"`
+	Conditions             []libsveltosv1alpha1.Condition `json:"conditions"`
+	EvaluatedAt            time.Time                      `json:"evaluatedAt"`
+}
+
+// ClusterHealthStatusList is the response served at ClusterHealthStatusesPath
+type ClusterHealthStatusList struct {
+	Kind  string                `json:"kind"`
+	Items []ClusterHealthStatus `json:"items"`
+}
+
+var (
+	clusterHealthStatusesMux sync.RWMutex
+	// clusterHealthStatuses contains the last evaluation result per ClusterHealthCheck/cluster pair
+	clusterHealthStatuses = map[string]*ClusterHealthStatus{}
+)
+
+// getFailingConditions returns the conditions reporting a liveness check which is not passing
+func getFailingConditions(conditions []libsveltosv1alpha1.Condition) []*libsveltosv1alpha1.Condition {
+	failing := make([]*libsveltosv1alpha1.Condition, 0)
+	for i := range conditions {
+		if conditions[i].Status != corev1.ConditionTrue {
+			failing = append(failing, &conditions[i])
+		}
+	}
+	return failing
+}
+
+// isHealthy returns true if all liveness checks are passing
+func isHealthy(conditions []libsveltosv1alpha1.Condition) bool {
+	return len(getFailingConditions(conditions)) == 0
+}
+
+// getHealthStatus returns HealthStatusHealthy if all liveness checks are passing, HealthStatusDegraded otherwise
+func getHealthStatus(conditions []libsveltosv1alpha1.Condition) string {
+	if isHealthy(conditions) {
+		return HealthStatusHealthy
+	}
+	return HealthStatusDegraded
+}
+
+func getClusterHealthStatusKey(chcName, clusterNamespace, clusterName string,
+	clusterType libsveltosv1alpha1.ClusterType) string {
+
+	return fmt.Sprintf("%s/%s:%s/%s", chcName, clusterType, clusterNamespace, clusterName)
+}
+
+// recordClusterHealthStatus stores in memory evaluation results for a ClusterHealthCheck/cluster pair
+func recordClusterHealthStatus(clusterNamespace, clusterName string, clusterType libsveltosv1alpha1.ClusterType,
+	chc *libsveltosv1alpha1.ClusterHealthCheck, conditions []libsveltosv1alpha1.Condition) {
+
+	status := &ClusterHealthStatus{
+		ClusterHealthCheckName: chc.Name,
+		ClusterNamespace:       clusterNamespace,
+		ClusterName:            clusterName,
+		ClusterType:            clusterType,
+		Healthy:                isHealthy(conditions),
+		Conditions:             conditions,
+		EvaluatedAt:            time.Now(),
+	}
+
+	clusterHealthStatusesMux.Lock()
+	defer clusterHealthStatusesMux.Unlock()
+
+	clusterHealthStatuses[getClusterHealthStatusKey(chc.Name, clusterNamespace, clusterName, clusterType)] = status
+}
+
+// removeClusterHealthStatus forgets evaluation results for a ClusterHealthCheck/cluster pair.
+// Invoked when ClusterHealthCheck is undeployed from cluster.
+func removeClusterHealthStatus(chcName, clusterNamespace, clusterName string, clusterType libsveltosv1alpha1.ClusterType) {
+	clusterHealthStatusesMux.Lock()
+	defer clusterHealthStatusesMux.Unlock()
+
+	delete(clusterHealthStatuses, getClusterHealthStatusKey(chcName, clusterNamespace, clusterName, clusterType))
+}
+
+func getClusterHealthStatusList() *ClusterHealthStatusList {
+	clusterHealthStatusesMux.RLock()
+	keys := make([]string, 0, len(clusterHealthStatuses))
+	for k := range clusterHealthStatuses {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	items := make([]ClusterHealthStatus, len(keys))
+	for i := range keys {
+		items[i] = *clusterHealthStatuses[keys[i]]
+	}
+	clusterHealthStatusesMux.RUnlock()
+
+	return &ClusterHealthStatusList{
+		Kind:  clusterHealthStatusListKind,
+		Items: items,
+	}
+}
+
+// ClusterHealthStatusHandler returns a read-only handler serving, from memory, the last
+// evaluation result of each ClusterHealthCheck for each matching cluster.
+// Dashboards can query it instead of reading ClusterHealthCheck status.
+func ClusterHealthStatusHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.Header().Set("Allow", http.MethodGet)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		data, err := json.Marshal(getClusterHealthStatusList())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(data)
+	})
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2024. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/projectsveltos/healthcheck-manager/controllers"
+	"github.com/projectsveltos/healthcheck-manager/controllers/testhelpers"
+	libsveltosv1alpha1 "github.com/projectsveltos/libsveltos/api/v1alpha1"
+)
+
+func getClusterHealthStatusList(url string) *controllers.ClusterHealthStatusList {
+	resp, err := http.Get(url + controllers.ClusterHealthStatusesPath)
+	Expect(err).To(BeNil())
+	defer resp.Body.Close()
+
+	Expect(resp.StatusCode).To(Equal(http.StatusOK))
+	Expect(resp.Header.Get("Content-Type")).To(Equal("application/json"))
+
+	list := &controllers.ClusterHealthStatusList{}
+	Expect(json.NewDecoder(resp.Body).Decode(list)).To(Succeed())
+	return list
+}
+
+func findClusterHealthStatus(list *controllers.ClusterHealthStatusList, chcName string) *controllers.ClusterHealthStatus {
+	for i := range list.Items {
+		if list.Items[i].ClusterHealthCheckName == chcName {
+			return &list.Items[i]
+		}
+	}
+	return nil
+}
+
+var _ = Describe("Cluster health statuses endpoint", func() {
+	var server *httptest.Server
+
+	BeforeEach(func() {
+		mux := http.NewServeMux()
+		mux.Handle(controllers.ClusterHealthStatusesPath, controllers.ClusterHealthStatusHandler())
+		server = httptest.NewServer(mux)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("serves last evaluation results as a ClusterHealthStatusList", func() {
+		clusterNamespace := randomString()
+		clusterName := randomString()
+		clusterType := libsveltosv1alpha1.ClusterTypeCapi
+
+		healthyChc := testhelpers.NewClusterHealthCheck().WithName(randomString()).Build()
+		degradedChc := testhelpers.NewClusterHealthCheck().WithName(randomString()).Build()
+		defer controllers.RemoveClusterHealthStatus(healthyChc.Name, clusterNamespace, clusterName, clusterType)
+		defer controllers.RemoveClusterHealthStatus(degradedChc.Name, clusterNamespace, clusterName, clusterType)
+
+		controllers.RecordClusterHealthStatus(clusterNamespace, clusterName, clusterType, healthyChc,
+			[]libsveltosv1alpha1.Condition{{Name: randomString(), Status: corev1.ConditionTrue}})
+		failingMessage := randomString()
+		controllers.RecordClusterHealthStatus(clusterNamespace, clusterName, clusterType, degradedChc,
+			[]libsveltosv1alpha1.Condition{
+				{Name: randomString(), Status: corev1.ConditionTrue},
+				{Name: randomString(), Status: corev1.ConditionFalse, Message: failingMessage},
+			})
+
+		list := getClusterHealthStatusList(server.URL)
+		Expect(list.Kind).To(Equal("ClusterHealthStatusList"))
+
+		status := findClusterHealthStatus(list, healthyChc.Name)
+		Expect(status).ToNot(BeNil())
+		Expect(status.Healthy).To(BeTrue())
+		Expect(status.ClusterNamespace).To(Equal(clusterNamespace))
+		Expect(status.ClusterName).To(Equal(clusterName))
+		Expect(status.ClusterType).To(Equal(clusterType))
+		Expect(status.EvaluatedAt.IsZero()).To(BeFalse())
+
+		status = findClusterHealthStatus(list, degradedChc.Name)
+		Expect(status).ToNot(BeNil())
+		Expect(status.Healthy).To(BeFalse())
+		Expect(len(status.Conditions)).To(Equal(2))
+		Expect(status.Conditions[1].Message).To(Equal(failingMessage))
+	})
+
+	It("does not serve evaluation results once removed", func() {
+		clusterNamespace := randomString()
+		clusterName := randomString()
+		clusterType := libsveltosv1alpha1.ClusterTypeSveltos
+		chc := testhelpers.NewClusterHealthCheck().WithName(randomString()).Build()
+
+		controllers.RecordClusterHealthStatus(clusterNamespace, clusterName, clusterType, chc, nil)
+		Expect(findClusterHealthStatus(getClusterHealthStatusList(server.URL), chc.Name)).ToNot(BeNil())
+
+		controllers.RemoveClusterHealthStatus(chc.Name, clusterNamespace, clusterName, clusterType)
+		Expect(findClusterHealthStatus(getClusterHealthStatusList(server.URL), chc.Name)).To(BeNil())
+	})
+
+	It("getHealthStatus reports Degraded when any liveness check is not passing", func() {
+		Expect(controllers.GetHealthStatus(nil)).To(Equal(controllers.HealthStatusHealthy))
+
+		conditions := []libsveltosv1alpha1.Condition{
+			{Name: randomString(), Status: corev1.ConditionTrue},
+		}
+		Expect(controllers.GetHealthStatus(conditions)).To(Equal(controllers.HealthStatusHealthy))
+		Expect(controllers.GetFailingConditions(conditions)).To(BeEmpty())
+
+		conditions = append(conditions,
+			libsveltosv1alpha1.Condition{Name: randomString(), Status: corev1.ConditionFalse},
+			libsveltosv1alpha1.Condition{Name: randomString(), Status: corev1.ConditionUnknown})
+		Expect(controllers.GetHealthStatus(conditions)).To(Equal(controllers.HealthStatusDegraded))
+
+		failing := controllers.GetFailingConditions(conditions)
+		Expect(len(failing)).To(Equal(2))
+		Expect(failing[0].Name).To(Equal(conditions[1].Name))
+		Expect(failing[1].Name).To(Equal(conditions[2].Name))
+	})
+
+	It("only allows GET requests", func() {
+		resp, err := http.Post(server.URL+controllers.ClusterHealthStatusesPath, "application/json", http.NoBody)
+		Expect(err).To(BeNil())
+		defer resp.Body.Close()
+
+		Expect(resp.StatusCode).To(Equal(http.StatusMethodNotAllowed))
+	})
+})
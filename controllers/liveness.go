@@ -91,9 +91,11 @@ func evaluateLivenessCheckHealthCheck(ctx context.Context, c client.Client, clus
 
 	if len(healthCheckReportList.Items) == 0 {
 		logger.V(logs.LogInfo).Info("did not find healthCheckReport")
+		setResourcesEvaluated(clusterNamespace, clusterName, clusterType, livenessCheck.LivenessSourceRef.Name, 0)
 		return false, "", err
 	}
 
+	resourcesEvaluated := 0
 	for i := range healthCheckReportList.Items {
 		hcr := &healthCheckReportList.Items[i]
 		if hcr.DeletionTimestamp.IsZero() {
@@ -102,9 +104,13 @@ func evaluateLivenessCheckHealthCheck(ctx context.Context, c client.Client, clus
 				allHealthy = false
 			}
 			message += msg
+			resourcesEvaluated += len(hcr.Spec.ResourceStatuses)
 		}
 	}
 
+	setResourcesEvaluated(clusterNamespace, clusterName, clusterType, livenessCheck.LivenessSourceRef.Name,
+		resourcesEvaluated)
+
 	return allHealthy, message, nil
 }
 
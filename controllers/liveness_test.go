@@ -25,6 +25,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/klog/v2/textlogger"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -32,6 +33,7 @@ import (
 
 	configv1alpha1 "github.com/projectsveltos/addon-controller/api/v1alpha1"
 	"github.com/projectsveltos/healthcheck-manager/controllers"
+	"github.com/projectsveltos/healthcheck-manager/controllers/testhelpers"
 	libsveltosv1alpha1 "github.com/projectsveltos/libsveltos/api/v1alpha1"
 )
 
@@ -124,14 +126,7 @@ var _ = Describe("Liveness", func() {
 	})
 
 	It("hasLivenessCheckStatusChange returns true when status was never evaluated before and status is different", func() {
-		chc := &libsveltosv1alpha1.ClusterHealthCheck{
-			ObjectMeta: metav1.ObjectMeta{
-				Name: randomString(),
-			},
-			Status: libsveltosv1alpha1.ClusterHealthCheckStatus{
-				ClusterConditions: []libsveltosv1alpha1.ClusterCondition{},
-			},
-		}
+		chc := testhelpers.NewClusterHealthCheck().WithName(randomString()).WithClusterConditions().Build()
 
 		clusterNamespace := randomString()
 		clusterName := randomString()
@@ -230,6 +225,74 @@ var _ = Describe("Liveness", func() {
 		Expect(passing).To(BeTrue())
 		Expect(statusChanged).To(BeTrue())
 	})
+
+	It("evaluateLivenessCheck records number of resources evaluated by HealthCheck", func() {
+		clusterNamespace := randomString()
+		clusterName := randomString()
+		clusterType := libsveltosv1alpha1.ClusterTypeCapi
+		healthCheckName := randomString()
+
+		hcr := &libsveltosv1alpha1.HealthCheckReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: clusterNamespace,
+				Name:      randomString(),
+				Labels:    libsveltosv1alpha1.GetHealthCheckReportLabels(healthCheckName, clusterName, &clusterType),
+			},
+			Spec: libsveltosv1alpha1.HealthCheckReportSpec{
+				ClusterNamespace: clusterNamespace,
+				ClusterName:      clusterName,
+				ClusterType:      clusterType,
+				HealthCheckName:  healthCheckName,
+				ResourceStatuses: []libsveltosv1alpha1.ResourceStatus{
+					{
+						ObjectRef:    corev1.ObjectReference{Kind: "Pod", Namespace: randomString(), Name: randomString()},
+						HealthStatus: libsveltosv1alpha1.HealthStatusHealthy,
+					},
+					{
+						ObjectRef:    corev1.ObjectReference{Kind: "Pod", Namespace: randomString(), Name: randomString()},
+						HealthStatus: libsveltosv1alpha1.HealthStatusHealthy,
+					},
+				},
+			},
+		}
+
+		c := prepareClientWithClusterSummaryAndCHC(clusterNamespace, clusterName, clusterType)
+		Expect(c.Create(context.TODO(), hcr)).To(Succeed())
+
+		livenessCheck := libsveltosv1alpha1.LivenessCheck{
+			Name: randomString(),
+			Type: libsveltosv1alpha1.LivenessTypeHealthCheck,
+			LivenessSourceRef: &corev1.ObjectReference{
+				Kind:       libsveltosv1alpha1.HealthCheckKind,
+				APIVersion: libsveltosv1alpha1.GroupVersion.String(),
+				Name:       healthCheckName,
+			},
+		}
+
+		chcs := &libsveltosv1alpha1.ClusterHealthCheckList{}
+		Expect(c.List(context.TODO(), chcs)).To(Succeed())
+		Expect(len(chcs.Items)).To(Equal(1))
+
+		logger := textlogger.NewLogger(textlogger.NewConfig(textlogger.Verbosity(1)))
+		passing, _, _, err := controllers.EvaluateLivenessCheck(context.TODO(), c, clusterNamespace, clusterName, clusterType,
+			&chcs.Items[0], &livenessCheck, logger)
+		Expect(err).To(BeNil())
+		Expect(passing).To(BeTrue())
+		Expect(controllers.GetResourcesEvaluated(clusterNamespace, clusterName, clusterType, healthCheckName)).To(Equal(float64(2)))
+
+		Expect(c.Get(context.TODO(), types.NamespacedName{Namespace: hcr.Namespace, Name: hcr.Name}, hcr)).To(Succeed())
+		hcr.Spec.ResourceStatuses = hcr.Spec.ResourceStatuses[:1]
+		Expect(c.Update(context.TODO(), hcr)).To(Succeed())
+
+		_, _, _, err = controllers.EvaluateLivenessCheck(context.TODO(), c, clusterNamespace, clusterName, clusterType,
+			&chcs.Items[0], &livenessCheck, logger)
+		Expect(err).To(BeNil())
+		Expect(controllers.GetResourcesEvaluated(clusterNamespace, clusterName, clusterType, healthCheckName)).To(Equal(float64(1)))
+
+		series := controllers.CountResourcesEvaluated()
+		controllers.ResetResourcesEvaluated(healthCheckName)
+		Expect(controllers.CountResourcesEvaluated()).To(Equal(series - 1))
+	})
 })
 
 // prepareClientWithClusterSummaryAndCHC creates a client with a ClusterSummary and a ClusterHealthCheck.
@@ -237,18 +300,12 @@ var _ = Describe("Liveness", func() {
 // ClusterSummary has provisioned all add-ons
 // Cluster API cluster
 func prepareClientWithClusterSummaryAndCHC(clusterNamespace, clusterName string, clusterType libsveltosv1alpha1.ClusterType) client.Client {
-	cluster := &clusterv1.Cluster{
-		ObjectMeta: metav1.ObjectMeta{
-			Namespace: clusterNamespace,
-			Name:      clusterName,
-		},
-		Status: clusterv1.ClusterStatus{
-			ControlPlaneReady: true,
-			Conditions: []clusterv1.Condition{
-				{Type: clusterv1.ControlPlaneInitializedCondition, Status: corev1.ConditionTrue},
-			},
-		},
-	}
+	cluster := testhelpers.NewCluster().
+		WithNamespace(clusterNamespace).
+		WithName(clusterName).
+		WithControlPlaneReady(true).
+		WithControlPlaneInitialized().
+		Build()
 
 	clusterSummary := &configv1alpha1.ClusterSummary{
 		ObjectMeta: metav1.ObjectMeta{
@@ -273,27 +330,17 @@ func prepareClientWithClusterSummaryAndCHC(clusterNamespace, clusterName string,
 		},
 	}
 
-	chc := &libsveltosv1alpha1.ClusterHealthCheck{
-		ObjectMeta: metav1.ObjectMeta{
+	chc := testhelpers.NewClusterHealthCheck().
+		WithName(randomString()).
+		WithLivenessCheck(libsveltosv1alpha1.LivenessCheck{
 			Name: randomString(),
-		},
-		Spec: libsveltosv1alpha1.ClusterHealthCheckSpec{
-			LivenessChecks: []libsveltosv1alpha1.LivenessCheck{
-				{
-					Name: randomString(),
-					Type: libsveltosv1alpha1.LivenessTypeAddons,
-				},
-			},
-		},
-		Status: libsveltosv1alpha1.ClusterHealthCheckStatus{
-			MatchingClusterRefs: []corev1.ObjectReference{
-				{
-					Kind: "Cluster", APIVersion: clusterv1.GroupVersion.String(), Namespace: clusterNamespace, Name: clusterName,
-				},
-			},
-			ClusterConditions: []libsveltosv1alpha1.ClusterCondition{},
-		},
-	}
+			Type: libsveltosv1alpha1.LivenessTypeAddons,
+		}).
+		WithMatchingClusterRefs(corev1.ObjectReference{
+			Kind: "Cluster", APIVersion: clusterv1.GroupVersion.String(), Namespace: clusterNamespace, Name: clusterName,
+		}).
+		WithClusterConditions().
+		Build()
 
 	initObjects := []client.Object{
 		clusterSummary,
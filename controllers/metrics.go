@@ -37,12 +37,74 @@ var (
 			Buckets:   []float64{0.1, 0.5, 1, 5, 10, 20, 30},
 		},
 	)
+
+	// No _total suffix: Prometheus reserves it for counters, while this value can go down.
+	// The cluster label prevents results of the same check on different clusters from overwriting each other.
+	resourcesEvaluatedGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "projectsveltos",
+			Name:      "clusterhealthcheck_resources_evaluated",
+			Help:      "Number of resources evaluated by a HealthCheck on a workload cluster during last evaluation",
+		},
+		[]string{"cluster", "check_name"},
+	)
+
+	publishFailures = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: "projectsveltos",
+			Name:      "clusterhealthcheck_publish_failures_total",
+			Help:      "Number of evaluation results which failed to be published",
+		},
+	)
+
+	reconcileErrors = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "projectsveltos",
+			Name:      "clusterhealthcheck_reconcile_errors_total",
+			Help:      "Number of ClusterHealthCheck reconciliations which returned an error, by error type",
+		},
+		[]string{"error_type"},
+	)
+
+	// Recovered panics are not counted as reconcile errors as well
+	reconcilePanics = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "projectsveltos",
+			Name:      "clusterhealthcheck_panics_total",
+			Help:      "Number of ClusterHealthCheck reconciliations and evaluations which panicked, by operation",
+		},
+		[]string{"operation"},
+	)
+
+	evaluationDurationHistogram = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Namespace: "projectsveltos",
+			Name:      "clusterhealthcheck_evaluation_duration_ms",
+			Help:      "Evaluate ClusterHealthCheck liveness checks on a workload cluster duration distribution (milliseconds)",
+			Buckets:   []float64{10, 50, 100, 500, 1000, 5000, 10000, 30000},
+		},
+	)
+
+	evaluationDurationAverageGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "projectsveltos",
+			Name:      "clusterhealthcheck_evaluation_duration_average_ms",
+			Help:      "Rolling average of the last evaluations duration of a ClusterHealthCheck on a workload cluster (milliseconds)",
+		},
+		[]string{"cluster", "check_name"},
+	)
 )
 
 //nolint:gochecknoinits // forced pattern, can't workaround
 func init() {
 	// Register custom metrics with the global prometheus registry
 	metrics.Registry.MustRegister(programClusterHealthCheckDurationHistogram)
+	metrics.Registry.MustRegister(resourcesEvaluatedGauge)
+	metrics.Registry.MustRegister(publishFailures)
+	metrics.Registry.MustRegister(reconcileErrors)
+	metrics.Registry.MustRegister(evaluationDurationHistogram)
+	metrics.Registry.MustRegister(reconcilePanics)
+	metrics.Registry.MustRegister(evaluationDurationAverageGauge)
 }
 
 func newClusterHealthCheckHistogram(clusterNamespace, clusterName string, clusterType libsveltosv1alpha1.ClusterType,
@@ -94,3 +156,30 @@ func programDuration(elapsed time.Duration, clusterNamespace, clusterName, featu
 		}
 	}
 }
+
+// setResourcesEvaluated records the number of resources a HealthCheck evaluated in a workload cluster
+func setResourcesEvaluated(clusterNamespace, clusterName string, clusterType libsveltosv1alpha1.ClusterType,
+	healthCheckName string, count int) {
+
+	clusterInfo := fmt.Sprintf("%s:%s/%s", clusterType, clusterNamespace, clusterName)
+	resourcesEvaluatedGauge.WithLabelValues(clusterInfo, healthCheckName).Set(float64(count))
+}
+
+// trackReconcileError increments the number of reconciliation errors for the category err belongs to
+func trackReconcileError(err error) {
+	reconcileErrors.WithLabelValues(getErrorType(err)).Inc()
+}
+
+// removeResourcesEvaluated removes data recorded for a HealthCheck in a cluster.
+func removeResourcesEvaluated(clusterNamespace, clusterName string, clusterType libsveltosv1alpha1.ClusterType,
+	healthCheckName string) {
+
+	clusterInfo := fmt.Sprintf("%s:%s/%s", clusterType, clusterNamespace, clusterName)
+	resourcesEvaluatedGauge.DeleteLabelValues(clusterInfo, healthCheckName)
+}
+
+// resetResourcesEvaluated removes, for all clusters, data recorded for a HealthCheck.
+// Invoked when HealthCheck is not referenced anymore by any ClusterHealthCheck.
+func resetResourcesEvaluated(healthCheckName string) {
+	resourcesEvaluatedGauge.DeletePartialMatch(prometheus.Labels{"check_name": healthCheckName})
+}
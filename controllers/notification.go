@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	goteamsnotify "github.com/atc0005/go-teams-notify/v2"
 	"github.com/atc0005/go-teams-notify/v2/adaptivecard"
@@ -35,6 +36,17 @@ import (
 	logs "github.com/projectsveltos/libsveltos/lib/logsettings"
 )
 
+const (
+	// Slack rejects with invalid_blocks any message exceeding one of the following limits
+	slackMaxHeaderLength  = 150
+	slackMaxSectionLength = 3000
+	slackMaxBlocks        = 50
+)
+
+var (
+	slackAPIURL = slack.APIURL
+)
+
 type slackInfo struct {
 	token     string
 	channelID string
@@ -67,7 +79,7 @@ func sendNotification(ctx context.Context, c client.Client, clusterNamespace, cl
 	case libsveltosv1alpha1.NotificationTypeKubernetesEvent:
 		sendKubernetesNotification(clusterNamespace, clusterName, clusterType, chc, conditions, logger)
 	case libsveltosv1alpha1.NotificationTypeSlack:
-		err = sendSlackNotification(ctx, c, clusterNamespace, clusterName, clusterType, n, conditions, logger)
+		err = sendSlackNotification(ctx, c, clusterNamespace, clusterName, clusterType, chc, n, conditions, logger)
 	case libsveltosv1alpha1.NotificationTypeWebex:
 		err = sendWebexNotification(ctx, c, clusterNamespace, clusterName, clusterType, n, conditions, logger)
 	case libsveltosv1alpha1.NotificationTypeDiscord:
@@ -105,8 +117,8 @@ func sendKubernetesNotification(clusterNamespace, clusterName string,
 }
 
 func sendSlackNotification(ctx context.Context, c client.Client, clusterNamespace, clusterName string,
-	clusterType libsveltosv1alpha1.ClusterType, n *libsveltosv1alpha1.Notification, conditions []libsveltosv1alpha1.Condition,
-	logger logr.Logger) error {
+	clusterType libsveltosv1alpha1.ClusterType, chc *libsveltosv1alpha1.ClusterHealthCheck,
+	n *libsveltosv1alpha1.Notification, conditions []libsveltosv1alpha1.Condition, logger logr.Logger) error {
 
 	info, err := getSlackInfo(ctx, c, n)
 	if err != nil {
@@ -116,16 +128,18 @@ func sendSlackNotification(ctx context.Context, c client.Client, clusterNamespac
 	l := logger.WithValues("channel", info.channelID)
 	l.V(logs.LogInfo).Info("send slack message")
 
-	message, _ := getNotificationMessage(clusterNamespace, clusterName, clusterType, conditions, logger)
+	message, passing := getNotificationMessage(clusterNamespace, clusterName, clusterType, conditions, logger)
+	blocks := getSlackBlocks(clusterNamespace, clusterName, clusterType, chc, conditions, passing, time.Now())
 
-	api := slack.New(info.token)
+	api := slack.New(info.token, slack.OptionAPIURL(slackAPIURL))
 	if api == nil {
 		l.V(logs.LogInfo).Info("failed to get slack client")
 	}
 
 	l.V(logs.LogDebug).Info(fmt.Sprintf("Sending message to channel %s", info.channelID))
 
-	_, _, err = api.PostMessage(info.channelID, slack.MsgOptionText(message, false))
+	// message is used as fallback by clients which cannot display blocks
+	_, _, err = api.PostMessage(info.channelID, slack.MsgOptionText(message, false), slack.MsgOptionBlocks(blocks...))
 	if err != nil {
 		l.V(logs.LogInfo).Info(fmt.Sprintf("Failed to send message. Error: %v", err))
 		return err
@@ -244,15 +258,12 @@ func sendTeamsNotification(ctx context.Context, c client.Client, clusterNamespac
 func getNotificationMessage(clusterNamespace, clusterName string, clusterType libsveltosv1alpha1.ClusterType,
 	conditions []libsveltosv1alpha1.Condition, logger logr.Logger) (string, bool) {
 
-	passing := true
+	failing := getFailingConditions(conditions)
+	passing := len(failing) == 0
 	message := fmt.Sprintf("cluster %s:%s/%s  \n", clusterType, clusterNamespace, clusterName)
-	for i := range conditions {
-		c := &conditions[i]
-		if c.Status != corev1.ConditionTrue {
-			passing = false
-			message += fmt.Sprintf("liveness check %q failing  \n", c.Type)
-			message += fmt.Sprintf("%s  \n", c.Message)
-		}
+	for _, c := range failing {
+		message += fmt.Sprintf("liveness check %q failing  \n", c.Type)
+		message += fmt.Sprintf("%s  \n", c.Message)
 	}
 
 	if passing {
@@ -265,6 +276,86 @@ func getNotificationMessage(clusterNamespace, clusterName string, clusterType li
 	return message, passing
 }
 
+// getSlackBlocks returns Slack Block Kit representation of liveness checks status for a cluster.
+// Header and sections are truncated to Slack limits. When there are more failing liveness checks
+// than Slack allows blocks, the last block reports how many were omitted.
+func getSlackBlocks(clusterNamespace, clusterName string, clusterType libsveltosv1alpha1.ClusterType,
+	chc *libsveltosv1alpha1.ClusterHealthCheck, conditions []libsveltosv1alpha1.Condition, passing bool,
+	evaluatedAt time.Time) []slack.Block {
+
+	status := ":white_check_mark: " + HealthStatusHealthy
+	if !passing {
+		status = ":red_circle: " + HealthStatusDegraded
+	}
+
+	fields := []*slack.TextBlockObject{
+		slack.NewTextBlockObject(slack.MarkdownType,
+			fmt.Sprintf("*Cluster*\n%s:%s/%s", clusterType, clusterNamespace, clusterName), false, false),
+		slack.NewTextBlockObject(slack.MarkdownType, fmt.Sprintf("*Status*\n%s", status), false, false),
+		slack.NewTextBlockObject(slack.MarkdownType,
+			fmt.Sprintf("*Evaluated at*\n%s", evaluatedAt.UTC().Format(time.RFC3339)), false, false),
+	}
+
+	blocks := []slack.Block{
+		slack.NewHeaderBlock(slack.NewTextBlockObject(slack.PlainTextType,
+			truncateString(fmt.Sprintf("ClusterHealthCheck %s", chc.Name), slackMaxHeaderLength), false, false)),
+		slack.NewSectionBlock(nil, fields, nil),
+	}
+
+	failing := getFailingConditions(conditions)
+	available := slackMaxBlocks - len(blocks)
+	if len(failing) > available {
+		// Keep one block to report omitted liveness checks
+		available--
+	}
+
+	for i, c := range failing {
+		if i == available {
+			text := fmt.Sprintf("...and %d more failing liveness checks", len(failing)-i)
+			blocks = append(blocks,
+				slack.NewSectionBlock(slack.NewTextBlockObject(slack.MarkdownType, text, false, false), nil, nil))
+			break
+		}
+
+		blocks = append(blocks,
+			slack.NewSectionBlock(slack.NewTextBlockObject(slack.MarkdownType, getSlackConditionText(c), false, false), nil, nil))
+	}
+
+	return blocks
+}
+
+// getSlackConditionText returns the text of the section reporting a failing liveness check,
+// with the liveness check message truncated so that the section fits Slack limit.
+func getSlackConditionText(c *libsveltosv1alpha1.Condition) string {
+	text := truncateString(fmt.Sprintf("liveness check *%s* failing", c.Type), slackMaxSectionLength)
+	if c.Message == "" {
+		return text
+	}
+
+	const codeBlockFormat = "\n```%s```"
+	available := slackMaxSectionLength - len([]rune(text)) - len([]rune(fmt.Sprintf(codeBlockFormat, "")))
+	if available <= 0 {
+		return text
+	}
+
+	return text + fmt.Sprintf(codeBlockFormat, truncateString(c.Message, available))
+}
+
+// truncateString returns s cut down to at most maxLength characters. When truncated, s ends with an ellipsis.
+func truncateString(s string, maxLength int) string {
+	runes := []rune(s)
+	if len(runes) <= maxLength {
+		return s
+	}
+
+	const ellipsis = "..."
+	if maxLength <= len(ellipsis) {
+		return string(runes[:maxLength])
+	}
+
+	return string(runes[:maxLength-len(ellipsis)]) + ellipsis
+}
+
 // buildNotificationStatusMap creates a map reporting notification status by walking over ClusterHealthCheck status
 func buildNotificationStatusMap(clusterNamespace, clusterName string,
 	clusterType libsveltosv1alpha1.ClusterType, chc *libsveltosv1alpha1.ClusterHealthCheck) map[string]libsveltosv1alpha1.NotificationStatus {
@@ -18,16 +18,26 @@ package controllers_test
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+	"unicode/utf8"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/slack-go/slack"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2/textlogger"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	"github.com/projectsveltos/healthcheck-manager/controllers"
+	"github.com/projectsveltos/healthcheck-manager/controllers/testhelpers"
 	libsveltosv1alpha1 "github.com/projectsveltos/libsveltos/api/v1alpha1"
 )
 
@@ -64,31 +74,25 @@ var _ = Describe("Notification", func() {
 
 		notificationName := randomString()
 
-		chc := &libsveltosv1alpha1.ClusterHealthCheck{
-			ObjectMeta: metav1.ObjectMeta{
-				Name: randomString(),
-			},
-			Status: libsveltosv1alpha1.ClusterHealthCheckStatus{
-				ClusterConditions: []libsveltosv1alpha1.ClusterCondition{
+		chc := testhelpers.NewClusterHealthCheck().
+			WithName(randomString()).
+			WithClusterConditions(libsveltosv1alpha1.ClusterCondition{
+				ClusterInfo: libsveltosv1alpha1.ClusterInfo{
+					Cluster: corev1.ObjectReference{
+						Namespace:  clusterNamespace,
+						Name:       clusterName,
+						Kind:       "Cluster",
+						APIVersion: clusterv1.GroupVersion.String(),
+					},
+				},
+				NotificationSummaries: []libsveltosv1alpha1.NotificationSummary{
 					{
-						ClusterInfo: libsveltosv1alpha1.ClusterInfo{
-							Cluster: corev1.ObjectReference{
-								Namespace:  clusterNamespace,
-								Name:       clusterName,
-								Kind:       "Cluster",
-								APIVersion: clusterv1.GroupVersion.String(),
-							},
-						},
-						NotificationSummaries: []libsveltosv1alpha1.NotificationSummary{
-							{
-								Name:   notificationName,
-								Status: libsveltosv1alpha1.NotificationStatusDelivered,
-							},
-						},
+						Name:   notificationName,
+						Status: libsveltosv1alpha1.NotificationStatusDelivered,
 					},
 				},
-			},
-		}
+			}).
+			Build()
 
 		result := controllers.BuildNotificationStatusMap(clusterNamespace, clusterName, clusterType, chc)
 		Expect(result).ToNot(BeNil())
@@ -176,4 +180,166 @@ var _ = Describe("Notification", func() {
 		Expect(controllers.GetSlackChannelID(slackInfo)).To(Equal(slackChannelID))
 		Expect(controllers.GetSlackToken(slackInfo)).To(Equal(slackToken))
 	})
+
+	It("getSlackBlocks reports cluster, status, evaluation time and failing liveness checks", func() {
+		chc := testhelpers.NewClusterHealthCheck().WithName(randomString()).Build()
+
+		conditions := []libsveltosv1alpha1.Condition{
+			{Type: libsveltosv1alpha1.ConditionType(randomString()), Status: corev1.ConditionTrue},
+			{Type: libsveltosv1alpha1.ConditionType(randomString()), Status: corev1.ConditionFalse, Message: randomString()},
+		}
+
+		evaluatedAt := time.Now()
+		clusterNamespace := randomString()
+		clusterName := randomString()
+		blocks := controllers.GetSlackBlocks(clusterNamespace, clusterName, libsveltosv1alpha1.ClusterTypeSveltos,
+			chc, conditions, false, evaluatedAt)
+		// header, summary and one section per failing liveness check
+		Expect(len(blocks)).To(Equal(3))
+
+		header, ok := blocks[0].(*slack.HeaderBlock)
+		Expect(ok).To(BeTrue())
+		Expect(header.Text.Text).To(ContainSubstring(chc.Name))
+
+		summary, ok := blocks[1].(*slack.SectionBlock)
+		Expect(ok).To(BeTrue())
+		Expect(len(summary.Fields)).To(Equal(3))
+		Expect(summary.Fields[0].Text).To(ContainSubstring(clusterNamespace + "/" + clusterName))
+		Expect(summary.Fields[1].Text).To(ContainSubstring("Degraded"))
+		Expect(summary.Fields[2].Text).To(ContainSubstring(evaluatedAt.UTC().Format(time.RFC3339)))
+
+		failing, ok := blocks[2].(*slack.SectionBlock)
+		Expect(ok).To(BeTrue())
+		Expect(failing.Text.Text).To(ContainSubstring(string(conditions[1].Type)))
+		Expect(failing.Text.Text).To(ContainSubstring(conditions[1].Message))
+
+		blocks = controllers.GetSlackBlocks(clusterNamespace, clusterName, libsveltosv1alpha1.ClusterTypeSveltos,
+			chc, conditions[:1], true, evaluatedAt)
+		Expect(len(blocks)).To(Equal(2))
+		summary, ok = blocks[1].(*slack.SectionBlock)
+		Expect(ok).To(BeTrue())
+		Expect(summary.Fields[1].Text).To(ContainSubstring("Healthy"))
+	})
+
+	It("getSlackBlocks truncates header and sections to Slack limits", func() {
+		chc := testhelpers.NewClusterHealthCheck().WithName(strings.Repeat("a", 200)).Build()
+
+		conditions := []libsveltosv1alpha1.Condition{
+			{
+				Type:    libsveltosv1alpha1.ConditionType(randomString()),
+				Status:  corev1.ConditionFalse,
+				Message: strings.Repeat("b", 5000),
+			},
+		}
+
+		blocks := controllers.GetSlackBlocks(randomString(), randomString(), libsveltosv1alpha1.ClusterTypeSveltos,
+			chc, conditions, false, time.Now())
+		Expect(len(blocks)).To(Equal(3))
+
+		header, ok := blocks[0].(*slack.HeaderBlock)
+		Expect(ok).To(BeTrue())
+		Expect(utf8.RuneCountInString(header.Text.Text)).To(BeNumerically("<=", 150))
+
+		failing, ok := blocks[2].(*slack.SectionBlock)
+		Expect(ok).To(BeTrue())
+		Expect(utf8.RuneCountInString(failing.Text.Text)).To(BeNumerically("<=", 3000))
+		Expect(failing.Text.Text).To(ContainSubstring(string(conditions[0].Type)))
+		Expect(failing.Text.Text).To(HaveSuffix("...```"))
+	})
+
+	It("getSlackBlocks never exceeds Slack maximum number of blocks", func() {
+		chc := testhelpers.NewClusterHealthCheck().WithName(randomString()).Build()
+
+		const failingChecks = 60
+		conditions := make([]libsveltosv1alpha1.Condition, failingChecks)
+		for i := range conditions {
+			conditions[i] = libsveltosv1alpha1.Condition{
+				Type:    libsveltosv1alpha1.ConditionType(randomString()),
+				Status:  corev1.ConditionFalse,
+				Message: randomString(),
+			}
+		}
+
+		blocks := controllers.GetSlackBlocks(randomString(), randomString(), libsveltosv1alpha1.ClusterTypeSveltos,
+			chc, conditions, false, time.Now())
+		Expect(len(blocks)).To(Equal(50))
+
+		// header, summary and 47 failing liveness checks. Last block reports omitted ones
+		last, ok := blocks[49].(*slack.SectionBlock)
+		Expect(ok).To(BeTrue())
+		Expect(last.Text.Text).To(ContainSubstring(fmt.Sprintf("and %d more", failingChecks-47)))
+
+		// exactly at the limit, no liveness check is omitted
+		blocks = controllers.GetSlackBlocks(randomString(), randomString(), libsveltosv1alpha1.ClusterTypeSveltos,
+			chc, conditions[:48], false, time.Now())
+		Expect(len(blocks)).To(Equal(50))
+		last, ok = blocks[49].(*slack.SectionBlock)
+		Expect(ok).To(BeTrue())
+		Expect(last.Text.Text).To(ContainSubstring(string(conditions[47].Type)))
+	})
+
+	It("sendSlackNotification posts Block Kit message", func() {
+		var postedBlocks slack.Blocks
+		var postedChannel string
+		var postedText string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer GinkgoRecover()
+			Expect(r.URL.Path).To(Equal("/chat.postMessage"))
+			Expect(r.ParseForm()).To(Succeed())
+			postedChannel = r.FormValue("channel")
+			postedText = r.FormValue("text")
+			Expect(json.Unmarshal([]byte(r.FormValue("blocks")), &postedBlocks)).To(Succeed())
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"ok":true,"channel":"` + postedChannel + `","ts":"1"}`))
+		}))
+		defer server.Close()
+
+		oldURL := controllers.SetSlackAPIURL(server.URL + "/")
+		defer controllers.SetSlackAPIURL(oldURL)
+
+		slackChannelID := randomString()
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      randomString(),
+				Namespace: randomString(),
+			},
+			Type: libsveltosv1alpha1.ClusterProfileSecretType,
+			Data: map[string][]byte{
+				libsveltosv1alpha1.SlackChannelID: []byte(slackChannelID),
+				libsveltosv1alpha1.SlackToken:     []byte(randomString()),
+			},
+		}
+
+		notification := &libsveltosv1alpha1.Notification{
+			Name: randomString(),
+			Type: libsveltosv1alpha1.NotificationTypeSlack,
+			NotificationRef: &corev1.ObjectReference{
+				Kind:       "Secret",
+				APIVersion: "v1",
+				Namespace:  secret.Namespace,
+				Name:       secret.Name,
+			},
+		}
+
+		chc := testhelpers.NewClusterHealthCheck().WithName(randomString()).Build()
+
+		conditions := []libsveltosv1alpha1.Condition{
+			{Type: libsveltosv1alpha1.ConditionType(randomString()), Status: corev1.ConditionTrue},
+		}
+
+		initObjects := []client.Object{
+			secret,
+		}
+
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(initObjects...).Build()
+
+		Expect(controllers.SendSlackNotification(context.TODO(), c, randomString(), randomString(),
+			libsveltosv1alpha1.ClusterTypeCapi, chc, notification, conditions,
+			textlogger.NewLogger(textlogger.NewConfig(textlogger.Verbosity(1))))).To(Succeed())
+		Expect(postedChannel).To(Equal(slackChannelID))
+		Expect(len(postedBlocks.BlockSet)).To(Equal(2))
+		Expect(postedBlocks.BlockSet[0].BlockType()).To(Equal(slack.MBTHeader))
+		// plain text fallback is always sent along with blocks
+		Expect(postedText).To(ContainSubstring("all liveness checks are passing"))
+	})
 })
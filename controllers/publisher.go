@@ -0,0 +1,129 @@
+/*
+Copyright 2024. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	libsveltosv1alpha1 "github.com/projectsveltos/libsveltos/api/v1alpha1"
+	logs "github.com/projectsveltos/libsveltos/lib/logsettings"
+)
+
+// HealthEvaluationEvent contains the result of evaluating a ClusterHealthCheck
+// for a given cluster.
+type HealthEvaluationEvent struct {
+	ClusterHealthCheckName string                         `json:"clusterHealthCheckName"`
+	ClusterNamespace       string                         `json:"clusterNamespace"`
+	ClusterName            string                         `json:"clusterName"`
+	ClusterType            libsveltosv1alpha1.ClusterType `json:"clusterType"`
+	Conditions             []libsveltosv1alpha1.Condition `json:"conditions"`
+	EvaluatedAt            time.Time                      `json:"evaluatedAt"`
+}
+
+// EvaluationPublisher publishes HealthEvaluationEvents to an external system
+type EvaluationPublisher interface {
+	Publish(ctx context.Context, event *HealthEvaluationEvent) error
+}
+
+var (
+	// publishTimeout is how long publishing a HealthEvaluationEvent can take before giving up.
+	// Evaluation results are published inline, so a slow external system must not stall
+	// ClusterHealthCheck evaluation.
+	publishTimeout = 10 * time.Second
+
+	evaluationPublisher EvaluationPublisher
+
+	healthTransitionPublisher EvaluationPublisher
+)
+
+// SetEvaluationPublisher sets the publisher used to deliver, after each evaluation,
+// a HealthEvaluationEvent. If never set, evaluation results are not published.
+func SetEvaluationPublisher(p EvaluationPublisher) {
+	evaluationPublisher = p
+}
+
+func getEvaluationPublisher() EvaluationPublisher {
+	return evaluationPublisher
+}
+
+// SetHealthTransitionPublisher sets the publisher used to deliver a HealthEvaluationEvent
+// only when evaluation results in a health transition (status of at least one LivenessCheck
+// changed). If never set, health transitions are not published.
+func SetHealthTransitionPublisher(p EvaluationPublisher) {
+	healthTransitionPublisher = p
+}
+
+func getHealthTransitionPublisher() EvaluationPublisher {
+	return healthTransitionPublisher
+}
+
+// publishEvaluation publishes evaluation results for a ClusterHealthCheck/cluster pair.
+// Failures are logged and counted but never returned, as evaluation itself succeeded.
+func publishEvaluation(ctx context.Context, clusterNamespace, clusterName string,
+	clusterType libsveltosv1alpha1.ClusterType, chc *libsveltosv1alpha1.ClusterHealthCheck,
+	conditions []libsveltosv1alpha1.Condition, logger logr.Logger) {
+
+	publisher := getEvaluationPublisher()
+	if publisher == nil {
+		return
+	}
+
+	doPublish(ctx, publisher, clusterNamespace, clusterName, clusterType, chc, conditions, logger)
+}
+
+// publishHealthTransition publishes evaluation results for a ClusterHealthCheck/cluster pair
+// when changed is true. Failures are logged and counted but never returned.
+func publishHealthTransition(ctx context.Context, clusterNamespace, clusterName string,
+	clusterType libsveltosv1alpha1.ClusterType, chc *libsveltosv1alpha1.ClusterHealthCheck,
+	conditions []libsveltosv1alpha1.Condition, changed bool, logger logr.Logger) {
+
+	publisher := getHealthTransitionPublisher()
+	if publisher == nil || !changed {
+		return
+	}
+
+	doPublish(ctx, publisher, clusterNamespace, clusterName, clusterType, chc, conditions, logger)
+}
+
+func doPublish(ctx context.Context, publisher EvaluationPublisher, clusterNamespace, clusterName string,
+	clusterType libsveltosv1alpha1.ClusterType, chc *libsveltosv1alpha1.ClusterHealthCheck,
+	conditions []libsveltosv1alpha1.Condition, logger logr.Logger) {
+
+	event := &HealthEvaluationEvent{
+		ClusterHealthCheckName: chc.Name,
+		ClusterNamespace:       clusterNamespace,
+		ClusterName:            clusterName,
+		ClusterType:            clusterType,
+		Conditions:             conditions,
+		EvaluatedAt:            time.Now(),
+	}
+
+	publishCtx, cancel := context.WithTimeout(ctx, publishTimeout)
+	defer cancel()
+
+	if err := publisher.Publish(publishCtx, event); err != nil {
+		logger.V(logs.LogInfo).Info(fmt.Sprintf("failed to publish evaluation results: %v", err))
+		publishFailures.Inc()
+		return
+	}
+
+	logger.V(logs.LogDebug).Info("published evaluation results")
+}
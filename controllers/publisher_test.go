@@ -0,0 +1,246 @@
+/*
+Copyright 2024. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"time"
+
+	"cloud.google.com/go/pubsub/apiv1/pubsubpb"
+	"cloud.google.com/go/pubsub/pstest"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/api/option"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2/textlogger"
+
+	"github.com/projectsveltos/healthcheck-manager/controllers"
+	"github.com/projectsveltos/healthcheck-manager/controllers/testhelpers"
+	libsveltosv1alpha1 "github.com/projectsveltos/libsveltos/api/v1alpha1"
+)
+
+type fakePublisher struct {
+	events []*controllers.HealthEvaluationEvent
+	err    error
+}
+
+func (p *fakePublisher) Publish(ctx context.Context, event *controllers.HealthEvaluationEvent) error {
+	if p.err != nil {
+		return p.err
+	}
+	p.events = append(p.events, event)
+	return nil
+}
+
+// blockingPublisher never completes a publish before ctx is done
+type blockingPublisher struct{}
+
+func (p *blockingPublisher) Publish(ctx context.Context, event *controllers.HealthEvaluationEvent) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+var _ = Describe("Publisher", func() {
+	var chc *libsveltosv1alpha1.ClusterHealthCheck
+	var conditions []libsveltosv1alpha1.Condition
+
+	BeforeEach(func() {
+		chc = testhelpers.NewClusterHealthCheck().WithName(randomString()).Build()
+
+		conditions = []libsveltosv1alpha1.Condition{
+			{
+				Name:   randomString(),
+				Type:   libsveltosv1alpha1.ConditionType(randomString()),
+				Status: corev1.ConditionTrue,
+			},
+		}
+	})
+
+	AfterEach(func() {
+		controllers.SetEvaluationPublisher(nil)
+		controllers.SetHealthTransitionPublisher(nil)
+	})
+
+	It("publishEvaluation publishes evaluation results", func() {
+		publisher := &fakePublisher{}
+		controllers.SetEvaluationPublisher(publisher)
+
+		clusterNamespace := randomString()
+		clusterName := randomString()
+		controllers.PublishEvaluation(context.TODO(), clusterNamespace, clusterName, libsveltosv1alpha1.ClusterTypeSveltos,
+			chc, conditions, textlogger.NewLogger(textlogger.NewConfig(textlogger.Verbosity(1))))
+
+		Expect(len(publisher.events)).To(Equal(1))
+		Expect(publisher.events[0].ClusterHealthCheckName).To(Equal(chc.Name))
+		Expect(publisher.events[0].ClusterNamespace).To(Equal(clusterNamespace))
+		Expect(publisher.events[0].ClusterName).To(Equal(clusterName))
+		Expect(publisher.events[0].ClusterType).To(Equal(libsveltosv1alpha1.ClusterTypeSveltos))
+		Expect(publisher.events[0].Conditions).To(Equal(conditions))
+	})
+
+	It("publishEvaluation does not fail when publishing fails", func() {
+		publisher := &fakePublisher{err: errors.New("unavailable")}
+		controllers.SetEvaluationPublisher(publisher)
+
+		failures := controllers.GetPublishFailures()
+		controllers.PublishEvaluation(context.TODO(), randomString(), randomString(), libsveltosv1alpha1.ClusterTypeCapi,
+			chc, conditions, textlogger.NewLogger(textlogger.NewConfig(textlogger.Verbosity(1))))
+
+		Expect(controllers.GetPublishFailures()).To(Equal(failures + 1))
+	})
+
+	It("publishEvaluation gives up when publishing takes too long", func() {
+		controllers.SetEvaluationPublisher(&blockingPublisher{})
+		oldTimeout := controllers.SetPublishTimeout(100 * time.Millisecond)
+		defer controllers.SetPublishTimeout(oldTimeout)
+
+		failures := controllers.GetPublishFailures()
+
+		done := make(chan struct{})
+		go func() {
+			defer GinkgoRecover()
+			defer close(done)
+			controllers.PublishEvaluation(context.TODO(), randomString(), randomString(), libsveltosv1alpha1.ClusterTypeCapi,
+				chc, conditions, textlogger.NewLogger(textlogger.NewConfig(textlogger.Verbosity(1))))
+		}()
+		Eventually(done, 5*time.Second).Should(BeClosed())
+
+		Expect(controllers.GetPublishFailures()).To(Equal(failures + 1))
+	})
+
+	It("publishHealthTransition publishes evaluation results only on health transitions", func() {
+		publisher := &fakePublisher{}
+		controllers.SetHealthTransitionPublisher(publisher)
+
+		logger := textlogger.NewLogger(textlogger.NewConfig(textlogger.Verbosity(1)))
+		clusterNamespace := randomString()
+		clusterName := randomString()
+
+		controllers.PublishHealthTransition(context.TODO(), clusterNamespace, clusterName, libsveltosv1alpha1.ClusterTypeSveltos,
+			chc, conditions, false, logger)
+		Expect(len(publisher.events)).To(Equal(0))
+
+		controllers.PublishHealthTransition(context.TODO(), clusterNamespace, clusterName, libsveltosv1alpha1.ClusterTypeSveltos,
+			chc, conditions, true, logger)
+		Expect(len(publisher.events)).To(Equal(1))
+		Expect(publisher.events[0].ClusterHealthCheckName).To(Equal(chc.Name))
+		Expect(publisher.events[0].ClusterNamespace).To(Equal(clusterNamespace))
+		Expect(publisher.events[0].ClusterName).To(Equal(clusterName))
+	})
+
+	It("SNSPublisher publishes JSON encoded evaluation results and message attributes to topic", func() {
+		var form url.Values
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_ = r.ParseForm()
+			form = r.PostForm
+			w.Header().Set("Content-Type", "text/xml")
+			_, _ = w.Write([]byte(`<PublishResponse xmlns="http://sns.amazonaws.com/doc/2010-03-31/">` +
+				`<PublishResult><MessageId>` + randomString() + `</MessageId></PublishResult>` +
+				`<ResponseMetadata><RequestId>` + randomString() + `</RequestId></ResponseMetadata></PublishResponse>`))
+		}))
+		defer srv.Close()
+
+		topicARN := "arn:aws:sns:us-east-1:000000000000:" + randomString()
+		publisher, err := controllers.NewSNSPublisher(context.TODO(), topicARN, func(o *sns.Options) {
+			o.BaseEndpoint = aws.String(srv.URL)
+			o.Region = "us-east-1"
+			o.Credentials = credentials.NewStaticCredentialsProvider(randomString(), randomString(), "")
+		})
+		Expect(err).To(BeNil())
+
+		conditions[0].Status = corev1.ConditionFalse
+		event := &controllers.HealthEvaluationEvent{
+			ClusterHealthCheckName: chc.Name,
+			ClusterNamespace:       randomString(),
+			ClusterName:            randomString(),
+			ClusterType:            libsveltosv1alpha1.ClusterTypeCapi,
+			Conditions:             conditions,
+		}
+		Expect(publisher.Publish(context.TODO(), event)).To(Succeed())
+
+		Expect(form).ToNot(BeNil())
+		Expect(form.Get("Action")).To(Equal("Publish"))
+		Expect(form.Get("TopicArn")).To(Equal(topicARN))
+
+		published := &controllers.SNSHealthEvent{}
+		Expect(json.Unmarshal([]byte(form.Get("Message")), published)).To(Succeed())
+		Expect(published.ClusterHealthCheckName).To(Equal(event.ClusterHealthCheckName))
+		Expect(published.ClusterNamespace).To(Equal(event.ClusterNamespace))
+		Expect(published.ClusterName).To(Equal(event.ClusterName))
+		Expect(published.HealthStatus).To(Equal(controllers.HealthStatusDegraded))
+
+		attributes := map[string]string{}
+		for i := 1; form.Get(fmt.Sprintf("MessageAttributes.entry.%d.Name", i)) != ""; i++ {
+			attributes[form.Get(fmt.Sprintf("MessageAttributes.entry.%d.Name", i))] =
+				form.Get(fmt.Sprintf("MessageAttributes.entry.%d.Value.StringValue", i))
+		}
+		Expect(attributes).To(HaveKeyWithValue(controllers.SNSClusterHealthCheckAttribute, event.ClusterHealthCheckName))
+		Expect(attributes).To(HaveKeyWithValue(controllers.SNSClusterNamespaceAttribute, event.ClusterNamespace))
+		Expect(attributes).To(HaveKeyWithValue(controllers.SNSClusterNameAttribute, event.ClusterName))
+		Expect(attributes).To(HaveKeyWithValue(controllers.SNSClusterTypeAttribute, string(event.ClusterType)))
+		Expect(attributes).To(HaveKeyWithValue(controllers.SNSHealthStatusAttribute, controllers.HealthStatusDegraded))
+	})
+
+	It("PubSubPublisher publishes JSON encoded evaluation results to topic", func() {
+		srv := pstest.NewServer()
+		defer srv.Close()
+
+		projectID := randomString()
+		topicID := randomString()
+		_, err := srv.GServer.CreateTopic(context.TODO(),
+			&pubsubpb.Topic{Name: fmt.Sprintf("projects/%s/topics/%s", projectID, topicID)})
+		Expect(err).To(BeNil())
+
+		conn, err := grpc.NewClient(srv.Addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		Expect(err).To(BeNil())
+
+		publisher, err := controllers.NewPubSubPublisher(context.TODO(), projectID, topicID, option.WithGRPCConn(conn))
+		Expect(err).To(BeNil())
+		defer publisher.Close()
+
+		event := &controllers.HealthEvaluationEvent{
+			ClusterHealthCheckName: chc.Name,
+			ClusterNamespace:       randomString(),
+			ClusterName:            randomString(),
+			ClusterType:            libsveltosv1alpha1.ClusterTypeCapi,
+			Conditions:             conditions,
+		}
+		Expect(publisher.Publish(context.TODO(), event)).To(Succeed())
+
+		messages := srv.Messages()
+		Expect(len(messages)).To(Equal(1))
+
+		published := &controllers.HealthEvaluationEvent{}
+		Expect(json.Unmarshal(messages[0].Data, published)).To(Succeed())
+		Expect(published.ClusterHealthCheckName).To(Equal(event.ClusterHealthCheckName))
+		Expect(published.ClusterNamespace).To(Equal(event.ClusterNamespace))
+		Expect(published.ClusterName).To(Equal(event.ClusterName))
+		Expect(published.Conditions[0].Name).To(Equal(conditions[0].Name))
+	})
+})
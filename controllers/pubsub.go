@@ -0,0 +1,65 @@
+/*
+Copyright 2024. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"cloud.google.com/go/pubsub"
+	"google.golang.org/api/option"
+)
+
+// PubSubPublisher publishes HealthEvaluationEvents, JSON encoded, to a Google Cloud Pub/Sub topic
+type PubSubPublisher struct {
+	client *pubsub.Client
+	topic  *pubsub.Topic
+}
+
+// NewPubSubPublisher returns a PubSubPublisher for topicID in the GCP project projectID
+func NewPubSubPublisher(ctx context.Context, projectID, topicID string,
+	opts ...option.ClientOption) (*PubSubPublisher, error) {
+
+	client, err := pubsub.NewClient(ctx, projectID, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create pubsub client: %w", err)
+	}
+
+	return &PubSubPublisher{
+		client: client,
+		topic:  client.Topic(topicID),
+	}, nil
+}
+
+// Publish publishes event and waits for the server to acknowledge it
+func (p *PubSubPublisher) Publish(ctx context.Context, event *HealthEvaluationEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal evaluation event: %w", err)
+	}
+
+	result := p.topic.Publish(ctx, &pubsub.Message{Data: data})
+	_, err = result.Get(ctx)
+	return err
+}
+
+// Close flushes pending messages and releases client resources
+func (p *PubSubPublisher) Close() error {
+	p.topic.Stop()
+	return p.client.Close()
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2024. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	snstypes "github.com/aws/aws-sdk-go-v2/service/sns/types"
+)
+
+const (
+	snsStringDataType = "String"
+
+	// SNS message attributes subscribers can use in filter policies
+	SNSClusterHealthCheckAttribute = "clusterHealthCheck"
+	SNSClusterNamespaceAttribute   = "clusterNamespace"
+	SNSClusterNameAttribute        = "clusterName"
+	SNSClusterTypeAttribute        = "clusterType"
+	SNSHealthStatusAttribute       = "healthStatus"
+)
+
+// SNSHealthEvent is the message, JSON encoded, published to the SNS topic
+type SNSHealthEvent struct {
+	HealthEvaluationEvent
+
+	// HealthStatus is either Healthy (all conditions are true) or Degraded
+	HealthStatus string `json:"healthStatus"`
+}
+
+// SNSPublisher publishes HealthEvaluationEvents, JSON encoded, to an AWS SNS topic
+type SNSPublisher struct {
+	client   *sns.Client
+	topicARN string
+}
+
+// NewSNSPublisher returns a SNSPublisher for topicARN. AWS credentials and region
+// are loaded using the default credentials chain.
+func NewSNSPublisher(ctx context.Context, topicARN string, optFns ...func(*sns.Options)) (*SNSPublisher, error) {
+	cfg, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS config: %w", err)
+	}
+
+	return &SNSPublisher{
+		client:   sns.NewFromConfig(cfg, optFns...),
+		topicARN: topicARN,
+	}, nil
+}
+
+// Publish publishes event. Cluster info and health status are also set as message
+// attributes, so subscribers can filter on them.
+func (p *SNSPublisher) Publish(ctx context.Context, event *HealthEvaluationEvent) error {
+	healthEvent := &SNSHealthEvent{
+		HealthEvaluationEvent: *event,
+		HealthStatus:          getHealthStatus(event.Conditions),
+	}
+
+	data, err := json.Marshal(healthEvent)
+	if err != nil {
+		return fmt.Errorf("failed to marshal evaluation event: %w", err)
+	}
+
+	_, err = p.client.Publish(ctx, &sns.PublishInput{
+		TopicArn: aws.String(p.topicARN),
+		Message:  aws.String(string(data)),
+		MessageAttributes: map[string]snstypes.MessageAttributeValue{
+			SNSClusterHealthCheckAttribute: getSNSStringAttribute(event.ClusterHealthCheckName),
+			SNSClusterNamespaceAttribute:   getSNSStringAttribute(event.ClusterNamespace),
+			SNSClusterNameAttribute:        getSNSStringAttribute(event.ClusterName),
+			SNSClusterTypeAttribute:        getSNSStringAttribute(string(event.ClusterType)),
+			SNSHealthStatusAttribute:       getSNSStringAttribute(healthEvent.HealthStatus),
+		},
+	})
+	return err
+}
+
+func getSNSStringAttribute(value string) snstypes.MessageAttributeValue {
+	return snstypes.MessageAttributeValue{
+		DataType:    aws.String(snsStringDataType),
+		StringValue: aws.String(value),
+	}
+}
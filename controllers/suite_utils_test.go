@@ -40,6 +40,7 @@ import (
 
 	configv1alpha1 "github.com/projectsveltos/addon-controller/api/v1alpha1"
 	"github.com/projectsveltos/healthcheck-manager/controllers"
+	"github.com/projectsveltos/healthcheck-manager/controllers/testhelpers"
 	libsveltosv1alpha1 "github.com/projectsveltos/libsveltos/api/v1alpha1"
 	libsveltosset "github.com/projectsveltos/libsveltos/lib/set"
 )
@@ -138,22 +139,16 @@ func getClusterHealthCheckReconciler(c client.Client) *controllers.ClusterHealth
 }
 
 func getHealthCheckInstance(name string) *libsveltosv1alpha1.HealthCheck {
-	return &libsveltosv1alpha1.HealthCheck{
-		ObjectMeta: metav1.ObjectMeta{
-			Name: name,
-		},
-		Spec: libsveltosv1alpha1.HealthCheckSpec{
-			ResourceSelectors: []libsveltosv1alpha1.ResourceSelector{
-				{
-					Group:    randomString(),
-					Version:  randomString(),
-					Kind:     randomString(),
-					Evaluate: randomString(),
-				},
-			},
-			EvaluateHealth: randomString(),
-		},
-	}
+	return testhelpers.NewHealthCheck().
+		WithName(name).
+		WithResourceSelectors(libsveltosv1alpha1.ResourceSelector{
+			Group:    randomString(),
+			Version:  randomString(),
+			Kind:     randomString(),
+			Evaluate: randomString(),
+		}).
+		WithEvaluateHealth(randomString()).
+		Build()
 }
 
 func getHealthCheckReport(healthCheckName, clusterNamespace, clusterName string) *libsveltosv1alpha1.HealthCheckReport {
@@ -199,12 +194,7 @@ func prepareCluster() *clusterv1.Cluster {
 	Expect(testEnv.Create(context.TODO(), ns)).To(Succeed())
 	Expect(waitForObject(context.TODO(), testEnv.Client, ns)).To(Succeed())
 
-	cluster := &clusterv1.Cluster{
-		ObjectMeta: metav1.ObjectMeta{
-			Namespace: namespace,
-			Name:      randomString(),
-		},
-	}
+	cluster := testhelpers.NewCluster().WithNamespace(namespace).WithName(randomString()).Build()
 
 	machine := &clusterv1.Machine{
 		ObjectMeta: metav1.ObjectMeta{
@@ -0,0 +1,324 @@
+/*
+Copyright 2024. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package testhelpers contains builders to create, in tests, the objects
+// ClusterHealthCheck predicates and reconcilers read.
+package testhelpers
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	libsveltosv1alpha1 "github.com/projectsveltos/libsveltos/api/v1alpha1"
+)
+
+// SveltosClusterBuilder builds SveltosCluster instances
+type SveltosClusterBuilder struct {
+	cluster *libsveltosv1alpha1.SveltosCluster
+}
+
+// NewSveltosCluster returns a SveltosClusterBuilder
+func NewSveltosCluster() *SveltosClusterBuilder {
+	return &SveltosClusterBuilder{cluster: &libsveltosv1alpha1.SveltosCluster{}}
+}
+
+// WithNamespace sets SveltosCluster namespace
+func (b *SveltosClusterBuilder) WithNamespace(namespace string) *SveltosClusterBuilder {
+	b.cluster.Namespace = namespace
+	return b
+}
+
+// WithName sets SveltosCluster name
+func (b *SveltosClusterBuilder) WithName(name string) *SveltosClusterBuilder {
+	b.cluster.Name = name
+	return b
+}
+
+// WithLabels sets SveltosCluster labels
+func (b *SveltosClusterBuilder) WithLabels(labels map[string]string) *SveltosClusterBuilder {
+	b.cluster.Labels = labels
+	return b
+}
+
+// WithAnnotations sets SveltosCluster annotations
+func (b *SveltosClusterBuilder) WithAnnotations(annotations map[string]string) *SveltosClusterBuilder {
+	b.cluster.Annotations = annotations
+	return b
+}
+
+// WithGeneration sets SveltosCluster generation
+func (b *SveltosClusterBuilder) WithGeneration(generation int64) *SveltosClusterBuilder {
+	b.cluster.Generation = generation
+	return b
+}
+
+// WithPaused sets SveltosCluster Spec.Paused
+func (b *SveltosClusterBuilder) WithPaused(paused bool) *SveltosClusterBuilder {
+	b.cluster.Spec.Paused = paused
+	return b
+}
+
+// WithKubeconfigName sets the name of the secret containing SveltosCluster kubeconfig
+func (b *SveltosClusterBuilder) WithKubeconfigName(name string) *SveltosClusterBuilder {
+	b.cluster.Spec.KubeconfigName = name
+	return b
+}
+
+// WithTokenRequestRenewalOption sets SveltosCluster Spec.TokenRequestRenewalOption
+func (b *SveltosClusterBuilder) WithTokenRequestRenewalOption(
+	option *libsveltosv1alpha1.TokenRequestRenewalOption) *SveltosClusterBuilder {
+
+	b.cluster.Spec.TokenRequestRenewalOption = option
+	return b
+}
+
+// WithReady sets SveltosCluster Status.Ready
+func (b *SveltosClusterBuilder) WithReady(ready bool) *SveltosClusterBuilder {
+	b.cluster.Status.Ready = ready
+	return b
+}
+
+// Build returns the SveltosCluster
+func (b *SveltosClusterBuilder) Build() *libsveltosv1alpha1.SveltosCluster {
+	return b.cluster.DeepCopy()
+}
+
+// ClusterBuilder builds ClusterAPI Cluster instances
+type ClusterBuilder struct {
+	cluster *clusterv1.Cluster
+}
+
+// NewCluster returns a ClusterBuilder
+func NewCluster() *ClusterBuilder {
+	return &ClusterBuilder{cluster: &clusterv1.Cluster{}}
+}
+
+// WithNamespace sets Cluster namespace
+func (b *ClusterBuilder) WithNamespace(namespace string) *ClusterBuilder {
+	b.cluster.Namespace = namespace
+	return b
+}
+
+// WithName sets Cluster name
+func (b *ClusterBuilder) WithName(name string) *ClusterBuilder {
+	b.cluster.Name = name
+	return b
+}
+
+// WithLabels sets Cluster labels
+func (b *ClusterBuilder) WithLabels(labels map[string]string) *ClusterBuilder {
+	b.cluster.Labels = labels
+	return b
+}
+
+// WithAnnotations sets Cluster annotations
+func (b *ClusterBuilder) WithAnnotations(annotations map[string]string) *ClusterBuilder {
+	b.cluster.Annotations = annotations
+	return b
+}
+
+// WithGeneration sets Cluster generation
+func (b *ClusterBuilder) WithGeneration(generation int64) *ClusterBuilder {
+	b.cluster.Generation = generation
+	return b
+}
+
+// WithPaused sets Cluster Spec.Paused. When paused, the ClusterAPI paused annotation is added as well.
+func (b *ClusterBuilder) WithPaused(paused bool) *ClusterBuilder {
+	b.cluster.Spec.Paused = paused
+	if paused {
+		if b.cluster.Annotations == nil {
+			b.cluster.Annotations = map[string]string{}
+		}
+		b.cluster.Annotations[clusterv1.PausedAnnotation] = "true"
+	} else {
+		delete(b.cluster.Annotations, clusterv1.PausedAnnotation)
+	}
+	return b
+}
+
+// WithControlPlaneRef sets Cluster Spec.ControlPlaneRef
+func (b *ClusterBuilder) WithControlPlaneRef(ref *corev1.ObjectReference) *ClusterBuilder {
+	b.cluster.Spec.ControlPlaneRef = ref
+	return b
+}
+
+// WithTopology sets Cluster Spec.Topology
+func (b *ClusterBuilder) WithTopology(topology *clusterv1.Topology) *ClusterBuilder {
+	b.cluster.Spec.Topology = topology
+	return b
+}
+
+// WithControlPlaneReady sets Cluster Status.ControlPlaneReady
+func (b *ClusterBuilder) WithControlPlaneReady(ready bool) *ClusterBuilder {
+	b.cluster.Status.ControlPlaneReady = ready
+	return b
+}
+
+// WithControlPlaneInitialized sets Cluster ControlPlaneInitialized condition to true
+func (b *ClusterBuilder) WithControlPlaneInitialized() *ClusterBuilder {
+	b.cluster.Status.Conditions = append(b.cluster.Status.Conditions, clusterv1.Condition{
+		Type:   clusterv1.ControlPlaneInitializedCondition,
+		Status: corev1.ConditionTrue,
+	})
+	return b
+}
+
+// WithObservedGeneration sets Cluster Status.ObservedGeneration
+func (b *ClusterBuilder) WithObservedGeneration(generation int64) *ClusterBuilder {
+	b.cluster.Status.ObservedGeneration = generation
+	return b
+}
+
+// Build returns the Cluster
+func (b *ClusterBuilder) Build() *clusterv1.Cluster {
+	return b.cluster.DeepCopy()
+}
+
+// ClusterHealthCheckBuilder builds ClusterHealthCheck instances
+type ClusterHealthCheckBuilder struct {
+	chc *libsveltosv1alpha1.ClusterHealthCheck
+}
+
+// NewClusterHealthCheck returns a ClusterHealthCheckBuilder
+func NewClusterHealthCheck() *ClusterHealthCheckBuilder {
+	return &ClusterHealthCheckBuilder{chc: &libsveltosv1alpha1.ClusterHealthCheck{}}
+}
+
+// WithName sets ClusterHealthCheck name
+func (b *ClusterHealthCheckBuilder) WithName(name string) *ClusterHealthCheckBuilder {
+	b.chc.Name = name
+	return b
+}
+
+// WithClusterSelector sets ClusterHealthCheck Spec.ClusterSelector
+func (b *ClusterHealthCheckBuilder) WithClusterSelector(selector string) *ClusterHealthCheckBuilder {
+	b.chc.Spec.ClusterSelector = libsveltosv1alpha1.Selector(selector)
+	return b
+}
+
+// WithLivenessCheck appends a LivenessCheck to ClusterHealthCheck Spec.LivenessChecks
+func (b *ClusterHealthCheckBuilder) WithLivenessCheck(
+	livenessCheck libsveltosv1alpha1.LivenessCheck) *ClusterHealthCheckBuilder {
+
+	b.chc.Spec.LivenessChecks = append(b.chc.Spec.LivenessChecks, livenessCheck)
+	return b
+}
+
+// WithHealthCheckLivenessCheck appends a LivenessCheck of type HealthCheck referencing healthCheckName
+func (b *ClusterHealthCheckBuilder) WithHealthCheckLivenessCheck(
+	livenessCheckName, healthCheckName string) *ClusterHealthCheckBuilder {
+
+	return b.WithLivenessCheck(libsveltosv1alpha1.LivenessCheck{
+		Name: livenessCheckName,
+		Type: libsveltosv1alpha1.LivenessTypeHealthCheck,
+		LivenessSourceRef: &corev1.ObjectReference{
+			APIVersion: libsveltosv1alpha1.GroupVersion.String(),
+			Kind:       libsveltosv1alpha1.HealthCheckKind,
+			Name:       healthCheckName,
+		},
+	})
+}
+
+// WithNotification appends a Notification to ClusterHealthCheck Spec.Notifications
+func (b *ClusterHealthCheckBuilder) WithNotification(
+	notification libsveltosv1alpha1.Notification) *ClusterHealthCheckBuilder {
+
+	b.chc.Spec.Notifications = append(b.chc.Spec.Notifications, notification)
+	return b
+}
+
+// WithMatchingClusterRefs sets ClusterHealthCheck Status.MatchingClusterRefs
+func (b *ClusterHealthCheckBuilder) WithMatchingClusterRefs(refs ...corev1.ObjectReference) *ClusterHealthCheckBuilder {
+	b.chc.Status.MatchingClusterRefs = refs
+	return b
+}
+
+// WithClusterConditions sets ClusterHealthCheck Status.ClusterConditions.
+// With no argument, Status.ClusterConditions is set to an empty, non nil, slice.
+func (b *ClusterHealthCheckBuilder) WithClusterConditions(
+	clusterConditions ...libsveltosv1alpha1.ClusterCondition) *ClusterHealthCheckBuilder {
+
+	if clusterConditions == nil {
+		clusterConditions = []libsveltosv1alpha1.ClusterCondition{}
+	}
+
+	b.chc.Status.ClusterConditions = clusterConditions
+	return b
+}
+
+// Build returns the ClusterHealthCheck
+func (b *ClusterHealthCheckBuilder) Build() *libsveltosv1alpha1.ClusterHealthCheck {
+	return b.chc.DeepCopy()
+}
+
+// HealthCheckBuilder builds HealthCheck instances
+type HealthCheckBuilder struct {
+	healthCheck *libsveltosv1alpha1.HealthCheck
+}
+
+// NewHealthCheck returns a HealthCheckBuilder
+func NewHealthCheck() *HealthCheckBuilder {
+	return &HealthCheckBuilder{healthCheck: &libsveltosv1alpha1.HealthCheck{}}
+}
+
+// WithName sets HealthCheck name
+func (b *HealthCheckBuilder) WithName(name string) *HealthCheckBuilder {
+	b.healthCheck.Name = name
+	return b
+}
+
+// WithLabels sets HealthCheck labels
+func (b *HealthCheckBuilder) WithLabels(labels map[string]string) *HealthCheckBuilder {
+	b.healthCheck.Labels = labels
+	return b
+}
+
+// WithAnnotations sets HealthCheck annotations
+func (b *HealthCheckBuilder) WithAnnotations(annotations map[string]string) *HealthCheckBuilder {
+	b.healthCheck.Annotations = annotations
+	return b
+}
+
+// WithResourceSelector appends a ResourceSelector to HealthCheck Spec.ResourceSelectors
+func (b *HealthCheckBuilder) WithResourceSelector(group, version, kind string) *HealthCheckBuilder {
+	b.healthCheck.Spec.ResourceSelectors = append(b.healthCheck.Spec.ResourceSelectors,
+		libsveltosv1alpha1.ResourceSelector{
+			Group:   group,
+			Version: version,
+			Kind:    kind,
+		})
+	return b
+}
+
+// WithResourceSelectors appends selectors to HealthCheck Spec.ResourceSelectors
+func (b *HealthCheckBuilder) WithResourceSelectors(
+	selectors ...libsveltosv1alpha1.ResourceSelector) *HealthCheckBuilder {
+
+	b.healthCheck.Spec.ResourceSelectors = append(b.healthCheck.Spec.ResourceSelectors, selectors...)
+	return b
+}
+
+// WithEvaluateHealth sets HealthCheck Spec.EvaluateHealth
+func (b *HealthCheckBuilder) WithEvaluateHealth(evaluateHealth string) *HealthCheckBuilder {
+	b.healthCheck.Spec.EvaluateHealth = evaluateHealth
+	return b
+}
+
+// Build returns the HealthCheck
+func (b *HealthCheckBuilder) Build() *libsveltosv1alpha1.HealthCheck {
+	return b.healthCheck.DeepCopy()
+}
@@ -0,0 +1,52 @@
+/*
+Copyright 2024. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	tracerName = "github.com/projectsveltos/healthcheck-manager"
+
+	reconcileSpanName = "ClusterHealthCheck.Reconcile"
+)
+
+// NewTracerProvider returns a TracerProvider sampling sampleRate (0.0 to 1.0) of the
+// reconciliations. Sampling decision of a parent span, if any, is honored.
+// Spans not sampled are non-recording, so they add no trace data.
+func NewTracerProvider(sampleRate float64, opts ...sdktrace.TracerProviderOption) (*sdktrace.TracerProvider, error) {
+	if sampleRate < 0 || sampleRate > 1 {
+		return nil, fmt.Errorf("trace sample rate must be between 0.0 and 1.0. Got %v", sampleRate)
+	}
+
+	opts = append(opts, sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(sampleRate))))
+	return sdktrace.NewTracerProvider(opts...), nil
+}
+
+// startReconcileSpan starts the span tracing a ClusterHealthCheck reconciliation.
+// Uses the global TracerProvider, which is a no-op one unless set with otel.SetTracerProvider.
+func startReconcileSpan(ctx context.Context, chcName string) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, reconcileSpanName,
+		trace.WithAttributes(attribute.String("clusterhealthcheck", chcName)))
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2024. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/projectsveltos/healthcheck-manager/controllers"
+)
+
+var _ = Describe("Tracing", func() {
+	var previous trace.TracerProvider
+
+	BeforeEach(func() {
+		previous = otel.GetTracerProvider()
+	})
+
+	AfterEach(func() {
+		otel.SetTracerProvider(previous)
+	})
+
+	It("NewTracerProvider rejects sample rates outside [0.0, 1.0]", func() {
+		_, err := controllers.NewTracerProvider(-0.1)
+		Expect(err).ToNot(BeNil())
+
+		_, err = controllers.NewTracerProvider(1.1)
+		Expect(err).ToNot(BeNil())
+
+		_, err = controllers.NewTracerProvider(0)
+		Expect(err).To(BeNil())
+	})
+
+	It("startReconcileSpan samples approximately the configured rate of reconciliations", func() {
+		const reconciles = 1000
+		const sampleRate = 0.1
+		const tolerance = 0.05
+
+		recorder := tracetest.NewSpanRecorder()
+		tracerProvider, err := controllers.NewTracerProvider(sampleRate, sdktrace.WithSpanProcessor(recorder))
+		Expect(err).To(BeNil())
+		otel.SetTracerProvider(tracerProvider)
+
+		notRecording := 0
+		for i := 0; i < reconciles; i++ {
+			_, span := controllers.StartReconcileSpan(context.TODO(), randomString())
+			if !span.IsRecording() {
+				notRecording++
+			}
+			span.End()
+		}
+
+		sampled := len(recorder.Ended())
+		Expect(sampled + notRecording).To(Equal(reconciles))
+		Expect(float64(sampled) / reconciles).To(BeNumerically("~", sampleRate, tolerance))
+	})
+
+	It("startReconcileSpan honors parent sampling decision", func() {
+		recorder := tracetest.NewSpanRecorder()
+		tracerProvider, err := controllers.NewTracerProvider(0, sdktrace.WithSpanProcessor(recorder))
+		Expect(err).To(BeNil())
+		otel.SetTracerProvider(tracerProvider)
+
+		// Rate is 0, so spans without a parent are never sampled
+		_, span := controllers.StartReconcileSpan(context.TODO(), randomString())
+		Expect(span.IsRecording()).To(BeFalse())
+		span.End()
+
+		// Parent is sampled, so child is sampled as well
+		parentCtx := trace.ContextWithSpanContext(context.TODO(), trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID:    trace.TraceID{1},
+			SpanID:     trace.SpanID{1},
+			TraceFlags: trace.FlagsSampled,
+			Remote:     true,
+		}))
+		_, span = controllers.StartReconcileSpan(parentCtx, randomString())
+		Expect(span.IsRecording()).To(BeTrue())
+		span.End()
+
+		Expect(recorder.Ended()).To(HaveLen(1))
+	})
+})